@@ -0,0 +1,284 @@
+// Command server is the HTTP entry point: it wires the database, every
+// internal/api handler, and the background workers (internal/daemon,
+// internal/stream) into one process and serves until a termination signal
+// asks it to shut down.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/timeline/backend/internal/api"
+	"github.com/timeline/backend/internal/auth"
+	"github.com/timeline/backend/internal/daemon"
+	"github.com/timeline/backend/internal/db"
+	"github.com/timeline/backend/internal/features"
+	"github.com/timeline/backend/internal/middleware"
+	"github.com/timeline/backend/internal/models"
+	"github.com/timeline/backend/internal/stream"
+)
+
+func main() {
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		log.Fatal("DATABASE_URL is required")
+	}
+
+	database, err := db.NewFromURL(databaseURL)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer database.Close()
+
+	repo := db.NewEventRepository(database)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Keeps every linked Twitter identity's cached tweets fresh by polling
+	// on an interval, per package daemon's doc comment.
+	registry := auth.NewRegistryFromEnv()
+	persister := daemon.NewTweetPersister(database, registry, 0)
+	go persister.Run(ctx)
+
+	// The filtered-stream ingester is opt-in: it needs its own elevated
+	// Twitter API access, so it only starts when that's configured.
+	var streamClient *stream.Client
+	if bearerToken := os.Getenv("TWITTER_STREAM_BEARER_TOKEN"); bearerToken != "" {
+		streamClient = stream.NewClient(database, bearerToken)
+		go streamClient.Run(ctx)
+		go consumeStream(ctx, database, streamClient)
+	}
+
+	router := newRouter(ctx, repo, database, streamClient)
+
+	addr := ":" + os.Getenv("PORT")
+	if addr == ":" {
+		addr = ":8080"
+	}
+	srv := &http.Server{Addr: addr, Handler: router}
+
+	go func() {
+		log.Printf("listening on %s", addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("server error: %v", err)
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	log.Println("shutting down...")
+	cancel()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer shutdownCancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("error during shutdown: %v", err)
+	}
+}
+
+// newRouter assembles every internal/api handler behind gin, matching the
+// "handles METHOD /path" doc comment on each method.
+func newRouter(ctx context.Context, repo *db.EventRepository, database *db.DB, streamClient *stream.Client) *gin.Engine {
+	router := gin.Default()
+
+	eventHandler := api.NewEventHandler(repo, database)
+	aggregateHandler := api.NewAggregateHandler(database, repo)
+	geoHandler := api.NewGeolocationHandler(database)
+	authHandler := api.NewAuthHandler(database)
+	relHandler := api.NewRelationshipHandler(repo)
+	adminHandler := api.NewAdminHandler(streamClient)
+
+	// Optional auth: attaches the caller's user to the context when a valid
+	// bearer token is present, without rejecting anonymous requests. Routes
+	// that require a signed-in caller layer RequireAuth/RequireRole on top.
+	router.Use(middleware.AuthMiddleware(database))
+
+	authGroup := router.Group("/auth")
+	{
+		authGroup.GET("/:provider/start", authHandler.StartOAuth)
+		authGroup.GET("/:provider/callback", authHandler.OAuthCallback)
+		authGroup.GET("/twitter/callback", authHandler.ExchangeCode)
+	}
+
+	apiGroup := router.Group("/api")
+	{
+		apiGroup.POST("/auth/login", authHandler.Login)
+		apiGroup.POST("/auth/refresh", authHandler.Refresh)
+		apiGroup.POST("/auth/logout", authHandler.Logout)
+		apiGroup.GET("/auth/me", authHandler.GetCurrentUser)
+		apiGroup.POST("/auth/twitter/callback", authHandler.TwitterCallback)
+
+		apiGroup.GET("/events", eventHandler.ListEvents)
+		apiGroup.POST("/events", eventHandler.CreateEvent)
+		apiGroup.GET("/events/stream", eventHandler.StreamEvents)
+		apiGroup.GET("/events/aggregate", aggregateHandler.GetAggregate)
+		apiGroup.GET("/events/aggregate/zoom", aggregateHandler.GetZoomAggregate)
+		apiGroup.POST("/events/batch", eventHandler.BatchEvents)
+		apiGroup.POST("/events/bulk", eventHandler.BulkCreateEvents)
+		apiGroup.POST("/events/bulk/upsert", eventHandler.BulkUpsertEvents)
+		apiGroup.GET("/events/locations/geojson", geoHandler.GetLocationsGeoJSON)
+		apiGroup.GET("/events/locations/tiles/:z/:x/:y.mvt", geoHandler.GetLocationsTile)
+		apiGroup.GET("/zoom-presets", eventHandler.GetZoomPresets)
+		apiGroup.GET("/categories/tree", eventHandler.GetCategoriesTree)
+		apiGroup.GET("/categories/:id/path", eventHandler.GetCategoryPath)
+		apiGroup.DELETE("/relations/:relationshipId", relHandler.DeleteRelationship)
+
+		apiGroup.GET("/events/:id", eventHandler.GetEvent)
+		apiGroup.PUT("/events/:id", eventHandler.UpdateEvent)
+		apiGroup.DELETE("/events/:id", eventHandler.DeleteEvent)
+		apiGroup.GET("/events/:id/history", eventHandler.GetEventHistory)
+		apiGroup.POST("/events/:id/revert", eventHandler.RevertEvent)
+		apiGroup.GET("/events/:id/relationships", eventHandler.GetEventRelationships)
+		apiGroup.POST("/events/:id/reconcile", eventHandler.ReconcileEvent)
+		apiGroup.GET("/events/:id/locations", geoHandler.GetEventLocations)
+		apiGroup.PUT("/events/:id/locations/primary", geoHandler.UpdateEventLocation)
+		apiGroup.POST("/events/:id/locations/:locId/enrich", geoHandler.EnrichLocation)
+		apiGroup.POST("/events/:id/relations", relHandler.CreateRelationship)
+		apiGroup.GET("/events/:id/relations/:type", relHandler.GetRelationshipsByType)
+		apiGroup.GET("/events/:id/relations/:type/recursive", relHandler.GetRelatedEvents)
+	}
+
+	// Experimental, opt-in APIs (see internal/features' doc comment) are
+	// enabled after the core routes so they can't shadow them.
+	webhooksFeature := features.NewWebhooksFeature()
+	featureRegistry := features.NewRegistry(&features.GraphTraversalFeature{}, webhooksFeature)
+	enabledFeatures := parseEnabledFeatures(os.Getenv("FEATURES_ENABLED"))
+	if err := featureRegistry.Enable(apiGroup, enabledFeatures, repo, database); err != nil {
+		log.Fatalf("failed to enable features: %v", err)
+	}
+	if contains(enabledFeatures, "webhooks") {
+		go dispatchWebhooks(ctx, webhooksFeature, eventHandler.Hub())
+	}
+
+	admin := router.Group("/admin")
+	admin.Use(middleware.RequireAuth, middleware.RequireRole("admin"))
+	{
+		admin.POST("/stream/reload", adminHandler.ReloadStream)
+	}
+
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	return router
+}
+
+// parseEnabledFeatures splits the comma-separated FEATURES_ENABLED env var
+// (e.g. "graph_traversal,webhooks") into feature names, dropping blanks so
+// an unset or trailing-comma value enables nothing.
+func parseEnabledFeatures(raw string) []string {
+	var enabled []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			enabled = append(enabled, name)
+		}
+	}
+	return enabled
+}
+
+// contains reports whether name is present in names.
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// dispatchWebhooks subscribes to every event lifecycle notification and
+// forwards it to webhooksFeature.Dispatch, so registered webhooks fire off
+// the same created/updated/deleted events StreamEvents sends to SSE clients.
+// Runs until ctx is cancelled.
+func dispatchWebhooks(ctx context.Context, webhooksFeature *features.WebhooksFeature, hub *api.EventHub) {
+	ch, unsubscribe := hub.Subscribe(models.EventQueryParams{})
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			webhooksFeature.Dispatch(msg.Type, msg.Event)
+		}
+	}
+}
+
+// consumeStream persists every tweet the filtered-stream ingester delivers
+// and logs its out-of-band errors. Runs until ctx is cancelled or the client
+// closes its channels.
+func consumeStream(ctx context.Context, database *db.DB, client *stream.Client) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case status, ok := <-client.Tweets:
+			if !ok {
+				return
+			}
+			if err := persistStreamedTweet(ctx, database, status); err != nil {
+				log.Printf("ERROR: failed to persist streamed tweet %s: %v", status.ID, err)
+			}
+
+		case err, ok := <-client.Errors:
+			if !ok {
+				continue
+			}
+			log.Printf("stream: %v", err)
+		}
+	}
+}
+
+// persistStreamedTweet resolves the tweet's author to a local user via
+// user_identities and caches it, mirroring the persistence TweetPersister
+// does for polled tweets, so it doesn't matter to downstream readers which
+// path a tweet arrived by.
+func persistStreamedTweet(ctx context.Context, database *db.DB, status *stream.TwitterStatus) error {
+	var userID string
+	err := database.QueryRowContext(ctx,
+		`SELECT user_id FROM user_identities WHERE provider = 'twitter' AND provider_user_id = $1`,
+		status.AuthorID,
+	).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return nil // tweet from a tracked rule we don't have a linked identity for
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resolve tweet author: %w", err)
+	}
+
+	_, err = database.ExecContext(ctx,
+		`INSERT INTO tweets (user_id, twitter_tweet_id, text, posted_at)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (user_id, twitter_tweet_id) DO NOTHING`,
+		userID, status.ID, status.Text, status.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store tweet: %w", err)
+	}
+
+	_, err = database.ExecContext(ctx,
+		`UPDATE user_identities SET last_tweet_id = $1 WHERE provider = 'twitter' AND user_id = $2`,
+		status.ID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to advance last_tweet_id: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timeline/backend/internal/stream"
+)
+
+// AdminHandler is AuthHandler's sibling for operator-only endpoints, meant to
+// be mounted behind middleware.RequireRole("admin") rather than normal auth.
+type AdminHandler struct {
+	streamClient *stream.Client
+}
+
+// NewAdminHandler builds an AdminHandler. streamClient may be nil if the
+// filtered-stream ingester isn't enabled in this deployment.
+func NewAdminHandler(streamClient *stream.Client) *AdminHandler {
+	return &AdminHandler{streamClient: streamClient}
+}
+
+// ReloadStream handles POST /admin/stream/reload, forcing the filtered-stream
+// ingestion worker to recompute its rules immediately instead of waiting for
+// its next reconnect, so a handle a user just linked starts being tracked
+// right away.
+func (h *AdminHandler) ReloadStream(c *gin.Context) {
+	if h.streamClient == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "stream ingestion is not enabled"})
+		return
+	}
+
+	h.streamClient.Reload()
+	c.JSON(http.StatusAccepted, gin.H{"status": "reload requested"})
+}
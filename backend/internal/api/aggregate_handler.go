@@ -0,0 +1,217 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"github.com/timeline/backend/internal/db"
+	"github.com/timeline/backend/internal/utils"
+)
+
+// AggregateHandler serves bucketed event counts for zoomable timeline views.
+type AggregateHandler struct {
+	db   *db.DB
+	repo *db.EventRepository
+}
+
+// NewAggregateHandler creates a new aggregate handler.
+func NewAggregateHandler(database *db.DB, repo *db.EventRepository) *AggregateHandler {
+	return &AggregateHandler{db: database, repo: repo}
+}
+
+// precisionForSpan picks the coarsest PrecisionLevel whose bucket width is no
+// larger than necessary for the requested span, walking the same ladder used
+// to label individual TimelineTime values.
+func precisionForSpan(spanYears decimal.Decimal) utils.PrecisionLevel {
+	switch {
+	case spanYears.GreaterThan(decimal.NewFromInt(1_000_000_000)):
+		return utils.PrecisionBillionYears
+	case spanYears.GreaterThan(decimal.NewFromInt(1_000_000)):
+		return utils.PrecisionMillionYears
+	case spanYears.GreaterThan(decimal.NewFromInt(1_000)):
+		return utils.PrecisionThousandYears
+	case spanYears.GreaterThan(decimal.NewFromInt(1)):
+		return utils.PrecisionYear
+	default:
+		return utils.PrecisionDay
+	}
+}
+
+// logBucketEdges returns the buckets+1 boundary values snapping bucket width
+// to a log scale over [from, to], so that each bucket covers a comparable
+// fraction of the span on a logarithmic axis rather than an equal number of
+// seconds. event_timeline_seconds is seconds-since-Big-Bang, so 0 is a valid
+// lower bound; shift by 1 second before taking the log to avoid log(0).
+func logBucketEdges(from, to decimal.Decimal, buckets int) []decimal.Decimal {
+	shift := decimal.NewFromInt(1)
+	loF, _ := from.Add(shift).Float64()
+	hiF, _ := to.Add(shift).Float64()
+	logLo := math.Log(loF)
+	logHi := math.Log(hiF)
+	step := (logHi - logLo) / float64(buckets)
+
+	edges := make([]decimal.Decimal, buckets+1)
+	edges[0] = from
+	edges[buckets] = to
+	for i := 1; i < buckets; i++ {
+		v := math.Exp(logLo + step*float64(i))
+		edges[i] = decimal.NewFromFloat(v).Sub(shift)
+	}
+	return edges
+}
+
+// AggregateBucket is a single bucket in the aggregated response.
+type AggregateBucket struct {
+	BucketIndex      int    `json:"bucket_index"`
+	StartSeconds     string `json:"start_seconds"`
+	EndSeconds       string `json:"end_seconds"`
+	Count            int    `json:"count"`
+	DominantCategory string `json:"dominant_category,omitempty"`
+	RepresentativeID string `json:"representative_event_id,omitempty"`
+}
+
+// GetAggregate handles GET /api/events/aggregate?from=<timeline_seconds>&to=<timeline_seconds>&buckets=N
+func (h *AggregateHandler) GetAggregate(c *gin.Context) {
+	fromStr := c.Query("from")
+	toStr := c.Query("to")
+	bucketsStr := c.DefaultQuery("buckets", "100")
+
+	from, err := decimal.NewFromString(fromStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'from' timeline_seconds"})
+		return
+	}
+	to, err := decimal.NewFromString(toStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'to' timeline_seconds"})
+		return
+	}
+	buckets, err := strconv.Atoi(bucketsStr)
+	if err != nil || buckets <= 0 || buckets > 10000 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "'buckets' must be a positive integer <= 10000"})
+		return
+	}
+	if to.LessThanOrEqual(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "'to' must be greater than 'from'"})
+		return
+	}
+
+	spanYears := to.Sub(from).Div(decimal.NewFromFloat(utils.SecondsPerYear))
+	precision := precisionForSpan(spanYears)
+
+	edges := logBucketEdges(from, to, buckets)
+	thresholds := make([]float64, buckets-1)
+	for i := 1; i < buckets; i++ {
+		f, _ := edges[i].Float64()
+		thresholds[i-1] = f
+	}
+
+	query := `
+		WITH bucketed AS (
+			SELECT
+				width_bucket(event_timeline_seconds::float8, $3::float8[]) + 1 AS bucket_index,
+				category,
+				id
+			FROM events
+			WHERE event_timeline_seconds >= $1 AND event_timeline_seconds <= $2
+		),
+		category_counts AS (
+			SELECT bucket_index, category, COUNT(*) AS n,
+			       ROW_NUMBER() OVER (PARTITION BY bucket_index ORDER BY COUNT(*) DESC) AS rn
+			FROM bucketed
+			GROUP BY bucket_index, category
+		),
+		representatives AS (
+			SELECT DISTINCT ON (bucket_index) bucket_index, id AS representative_id
+			FROM bucketed
+			ORDER BY bucket_index, id
+		)
+		SELECT
+			b.bucket_index,
+			COUNT(*) AS count,
+			MAX(cc.category) FILTER (WHERE cc.rn = 1) AS dominant_category,
+			MAX(r.representative_id) AS representative_id
+		FROM bucketed b
+		LEFT JOIN category_counts cc ON cc.bucket_index = b.bucket_index AND cc.rn = 1
+		LEFT JOIN representatives r ON r.bucket_index = b.bucket_index
+		GROUP BY b.bucket_index
+		ORDER BY b.bucket_index
+	`
+
+	rows, err := h.db.Query(query, from, to, pq.Array(thresholds))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to aggregate events", "details": err.Error()})
+		return
+	}
+	defer rows.Close()
+
+	results := []AggregateBucket{}
+
+	for rows.Next() {
+		var bucketIndex int
+		var count int
+		var dominantCategory, representativeID *string
+
+		if err := rows.Scan(&bucketIndex, &count, &dominantCategory, &representativeID); err != nil {
+			continue
+		}
+
+		bucketStart := edges[bucketIndex-1]
+		bucketEnd := edges[bucketIndex]
+
+		bucket := AggregateBucket{
+			BucketIndex:  bucketIndex,
+			StartSeconds: bucketStart.String(),
+			EndSeconds:   bucketEnd.String(),
+			Count:        count,
+		}
+		if dominantCategory != nil {
+			bucket.DominantCategory = *dominantCategory
+		}
+		if representativeID != nil {
+			bucket.RepresentativeID = *representativeID
+		}
+
+		results = append(results, bucket)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"buckets":   results,
+		"precision": precision,
+	})
+}
+
+// GetZoomAggregate handles GET /api/events/aggregate/zoom?preset=<name>&buckets=N,
+// EventRepository.ListAggregated's importance-ranked counterpart to
+// GetAggregate: it returns a count per bucket plus the top-importance
+// representative events in each one, scoped to the named zoom preset's span
+// and min_importance_threshold (see GetZoomPresets).
+func (h *AggregateHandler) GetZoomAggregate(c *gin.Context) {
+	preset := c.Query("preset")
+	if preset == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "'preset' is required"})
+		return
+	}
+
+	bucketsStr := c.DefaultQuery("buckets", "100")
+	buckets, err := strconv.Atoi(bucketsStr)
+	if err != nil || buckets <= 0 || buckets > 10000 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "'buckets' must be a positive integer <= 10000"})
+		return
+	}
+
+	histogram, representatives, err := h.repo.GetZoomPresetAggregate(preset, buckets)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown zoom preset", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"buckets": histogram,
+		"events":  representatives,
+	})
+}
@@ -1,30 +1,35 @@
 package api
 
 import (
-	"crypto/sha256"
+	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
-	"io"
-	"log"
 	"net/http"
-	"net/url"
-	"os"
-	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"github.com/timeline/backend/internal/auth"
 	"github.com/timeline/backend/internal/db"
+	"github.com/timeline/backend/internal/middleware"
 	"github.com/timeline/backend/internal/models"
+	"github.com/timeline/backend/internal/utils"
 )
 
+// accessTokenTTL is how long an issued JWT is valid for before it needs a refresh.
+const accessTokenTTL = 15 * time.Minute
+
+// sessionTTL is how long the backing session row (and therefore refreshability) lasts.
+const sessionTTL = middleware.MaxSessionLifetime
+
 type AuthHandler struct {
-	database *db.DB
+	database  *db.DB
+	providers *auth.Registry
 }
 
 func NewAuthHandler(database *db.DB) *AuthHandler {
-	return &AuthHandler{database: database}
+	return &AuthHandler{database: database, providers: auth.NewRegistryFromEnv()}
 }
 
 // TwitterCallbackRequest represents the data sent from frontend after Twitter OAuth
@@ -52,52 +57,35 @@ type ExchangeCodeRequest struct {
 	CodeVerifier string `json:"code_verifier"`
 }
 
-// ExchangeCode exchanges an OAuth authorization code for a session token
+// ExchangeCode handles the Twitter-specific OAuth callback route
+// (GET /auth/twitter/callback), kept for existing frontend integrations. It
+// delegates to the generic per-provider OAuthCallback (see oauth_pkce.go),
+// which validates the signed PKCE state cookie StartOAuth stored before
+// exchanging the code with the twitter Provider.
 func (h *AuthHandler) ExchangeCode(c *gin.Context) {
-	var req ExchangeCodeRequest
+	c.Params = append(c.Params, gin.Param{Key: "provider", Value: "twitter"})
+	h.OAuthCallback(c)
+}
+
+// TwitterCallback handles OAuth callback from frontend
+// Creates or updates user in database and returns session token
+func (h *AuthHandler) TwitterCallback(c *gin.Context) {
+	var req TwitterCallbackRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
 		return
 	}
 
-	codeSnip := req.Code
-	if len(req.Code) > 20 {
-		codeSnip = req.Code[:20] + "..."
-	}
-	log.Printf("Received exchange-code request: code=%s", codeSnip)
-
-	// Exchange code for access token with Twitter API
-	twitterResp, err := exchangeCodeForAccessToken(req.Code, req.CodeVerifier)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Failed to exchange code: " + err.Error()})
-		return
-	}
-
-	// Get user info from Twitter API
-	userInfo, err := getUserInfoFromTwitter(twitterResp.AccessToken)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Failed to get user info: " + err.Error()})
-		return
-	}
-
-	// Create or update user in database
-	callbackReq := TwitterCallbackRequest{
-		TwitterID:   userInfo.ID,
-		Username:    userInfo.Username,
-		DisplayName: userInfo.Name,
-		AvatarURL:   userInfo.ProfileImageURL,
-		IsVerified:  userInfo.Verified,
-	}
-
 	// Generate deterministic user ID from Twitter ID
-	userID := h.generateUserID(callbackReq.TwitterID)
+	// This ensures same Twitter user always gets same UUID across sessions
+	userID := h.generateUserID("twitter", req.TwitterID)
 
 	// Check if user exists
 	var existingUser models.User
-	err = h.database.QueryRow(
+	err := h.database.QueryRow(
 		"SELECT id, x_user_id, username, display_name, avatar_url, is_twitter_verified FROM users WHERE x_user_id = $1",
-		callbackReq.TwitterID,
+		req.TwitterID,
 	).Scan(
 		&existingUser.ID,
 		&existingUser.XUserID,
@@ -111,15 +99,15 @@ func (h *AuthHandler) ExchangeCode(c *gin.Context) {
 
 	if err == sql.ErrNoRows {
 		// Create new user
-		xUserID := callbackReq.TwitterID
+		xUserID := req.TwitterID
 		user = models.User{
 			ID:                userID,
 			XUserID:           &xUserID,
-			Username:          &callbackReq.Username,
-			DisplayName:       &callbackReq.DisplayName,
-			AvatarURL:         &callbackReq.AvatarURL,
+			Username:          &req.Username,
+			DisplayName:       &req.DisplayName,
+			AvatarURL:         &req.AvatarURL,
 			IsActive:          true,
-			IsTwitterVerified: callbackReq.IsVerified,
+			IsTwitterVerified: req.IsVerified,
 			CreatedAt:         time.Now(),
 		}
 
@@ -140,10 +128,10 @@ func (h *AuthHandler) ExchangeCode(c *gin.Context) {
 	} else {
 		// Update existing user with latest data from Twitter
 		user = existingUser
-		user.Username = &callbackReq.Username
-		user.DisplayName = &callbackReq.DisplayName
-		user.AvatarURL = &callbackReq.AvatarURL
-		user.IsTwitterVerified = callbackReq.IsVerified
+		user.Username = &req.Username
+		user.DisplayName = &req.DisplayName
+		user.AvatarURL = &req.AvatarURL
+		user.IsTwitterVerified = req.IsVerified
 
 		_, err := h.database.Exec(
 			`UPDATE users SET username = $1, display_name = $2, avatar_url = $3, is_twitter_verified = $4, last_login_at = $5 WHERE id = $6`,
@@ -170,123 +158,155 @@ func (h *AuthHandler) ExchangeCode(c *gin.Context) {
 		avatarURL = *user.AvatarURL
 	}
 
+	sessionToken, err := h.issueSession(user.ID, c.Request.UserAgent(), c.ClientIP(), time.Time{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create session: " + err.Error()})
+		return
+	}
+
 	response := AuthResponse{
 		UserID:       user.ID,
 		Username:     username,
 		DisplayName:  displayName,
 		AvatarURL:    avatarURL,
 		IsVerified:   user.IsTwitterVerified,
-		SessionToken: user.ID, // Session token is the user ID
+		SessionToken: sessionToken,
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
-// TwitterCallback handles OAuth callback from frontend
-// Creates or updates user in database and returns session token
-func (h *AuthHandler) TwitterCallback(c *gin.Context) {
-	var req TwitterCallbackRequest
+// newJTI generates a random 16-byte session identifier, hex-encoded.
+func newJTI() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
 
+// issueSession creates a new sessions row for userID, recording the request's
+// user agent and IP for later audit, and returns a signed JWT bound to it.
+// chainStartedAt is when the caller first logged in; a zero value means this
+// call starts a brand-new chain (e.g. Login), while Refresh passes through
+// the original session's chain_started_at so MaxSessionLifetime is enforced
+// as an absolute cap across rotations rather than resetting on each refresh.
+func (h *AuthHandler) issueSession(userID, userAgent, ip string, chainStartedAt time.Time) (string, error) {
+	jti, err := newJTI()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate session id: %w", err)
+	}
+
+	now := time.Now()
+	if chainStartedAt.IsZero() {
+		chainStartedAt = now
+	}
+	_, err = h.database.Exec(
+		`INSERT INTO sessions (jti, user_id, created_at, expires_at, user_agent, ip, chain_started_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		jti, userID, now, now.Add(sessionTTL), userAgent, ip, chainStartedAt,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return middleware.IssueToken(userID, jti, nil, accessTokenTTL)
+}
+
+// Login handles POST /api/auth/login
+// Exchanges an X (Twitter) OAuth code, upserts the user, and issues a signed
+// session token backed by a row in `sessions` (replacing the raw-user-id bearer scheme).
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req ExchangeCodeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
 		return
 	}
 
-	// Generate deterministic user ID from Twitter ID
-	// This ensures same Twitter user always gets same UUID across sessions
-	userID := h.generateUserID(req.TwitterID)
-
-	// Check if user exists
-	var existingUser models.User
-	err := h.database.QueryRow(
-		"SELECT id, x_user_id, username, display_name, avatar_url, is_twitter_verified FROM users WHERE x_user_id = $1",
-		req.TwitterID,
-	).Scan(
-		&existingUser.ID,
-		&existingUser.XUserID,
-		&existingUser.Username,
-		&existingUser.DisplayName,
-		&existingUser.AvatarURL,
-		&existingUser.IsTwitterVerified,
-	)
+	provider, ok := h.providers.Get("twitter")
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Twitter OAuth is not configured"})
+		return
+	}
 
-	var user models.User
+	token, err := provider.Exchange(c.Request.Context(), req.Code, req.CodeVerifier)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Failed to exchange code: " + err.Error()})
+		return
+	}
 
-	if err == sql.ErrNoRows {
-		// Create new user
-		xUserID := req.TwitterID
-		user = models.User{
-			ID:                userID,
-			XUserID:           &xUserID,
-			Username:          &req.Username,
-			DisplayName:       &req.DisplayName,
-			AvatarURL:         &req.AvatarURL,
-			IsActive:          true,
-			IsTwitterVerified: req.IsVerified,
-			CreatedAt:         time.Now(),
-		}
+	extUser, err := provider.FetchUser(c.Request.Context(), token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Failed to get user info: " + err.Error()})
+		return
+	}
 
-		_, err := h.database.Exec(
-			`INSERT INTO users (id, x_user_id, username, display_name, avatar_url, is_active, is_twitter_verified, created_at)
-			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
-			user.ID, user.XUserID, user.Username, user.DisplayName,
-			user.AvatarURL, user.IsActive, user.IsTwitterVerified, user.CreatedAt,
-		)
+	user, err := h.upsertIdentityUser(provider.Name(), extUser, token)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user: " + err.Error()})
-			return
-		}
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error: " + err.Error()})
+	sessionToken, err := h.issueSession(user.ID, c.Request.UserAgent(), c.ClientIP(), time.Time{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
-	} else {
-		// Update existing user with latest data from Twitter
-		user = existingUser
-		user.Username = &req.Username
-		user.DisplayName = &req.DisplayName
-		user.AvatarURL = &req.AvatarURL
-		user.IsTwitterVerified = req.IsVerified
+	}
 
-		_, err := h.database.Exec(
-			`UPDATE users SET username = $1, display_name = $2, avatar_url = $3, is_twitter_verified = $4, last_login_at = $5 WHERE id = $6`,
-			user.Username, user.DisplayName, user.AvatarURL, user.IsTwitterVerified, time.Now(), user.ID,
-		)
+	c.JSON(http.StatusOK, authResponseFromUser(user, sessionToken))
+}
 
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user: " + err.Error()})
-			return
-		}
+// Refresh handles POST /api/auth/refresh
+// Rotates the caller's session: the old session row is revoked and a new one
+// (with a new jti and JWT) is issued, so a leaked access token stops working
+// once it expires rather than being indefinitely reusable.
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
 	}
 
-	// Return user data and session token (which is their user ID)
-	username := ""
-	displayName := ""
-	avatarURL := ""
-	if user.Username != nil {
-		username = *user.Username
+	var chainStartedAt time.Time
+	err := h.database.QueryRow(`SELECT chain_started_at FROM sessions WHERE jti = $1`, claims.JTI).Scan(&chainStartedAt)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Session not found"})
+		return
 	}
-	if user.DisplayName != nil {
-		displayName = *user.DisplayName
+	if time.Since(chainStartedAt) > middleware.MaxSessionLifetime {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Session exceeded maximum lifetime, please log in again"})
+		return
 	}
-	if user.AvatarURL != nil {
-		avatarURL = *user.AvatarURL
+
+	_, err = h.database.Exec(`UPDATE sessions SET revoked_at = NOW() WHERE jti = $1`, claims.JTI)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate session"})
+		return
 	}
 
-	response := AuthResponse{
-		UserID:       user.ID,
-		Username:     username,
-		DisplayName:  displayName,
-		AvatarURL:    avatarURL,
-		IsVerified:   user.IsTwitterVerified,
-		SessionToken: user.ID, // Session token is the user ID
+	sessionToken, err := h.issueSession(claims.UserID, c.Request.UserAgent(), c.ClientIP(), chainStartedAt)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, gin.H{"session_token": sessionToken})
 }
 
-// Logout endpoint (optional, mostly for frontend to clear local storage)
+// Logout handles POST /api/auth/logout
+// Revokes the session backing the caller's current access token.
 func (h *AuthHandler) Logout(c *gin.Context) {
+	claims := middleware.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+		return
+	}
+
+	_, err := h.database.Exec(`UPDATE sessions SET revoked_at = NOW() WHERE jti = $1`, claims.JTI)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke session"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
 }
 
@@ -323,119 +343,137 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// generateUserID creates a deterministic UUID from Twitter ID
-// This ensures the same Twitter user always maps to the same UUID
-func (h *AuthHandler) generateUserID(twitterID string) string {
-	// Create a deterministic hash from Twitter ID
-	hash := sha256.Sum256([]byte("timeline-user:" + twitterID))
-	hashStr := hex.EncodeToString(hash[:])
-
-	// Format as UUID v5-like format (8-4-4-4-12)
-	return fmt.Sprintf(
-		"%s-%s-%s-%s-%s",
-		hashStr[0:8],
-		hashStr[8:12],
-		hashStr[12:16],
-		hashStr[16:20],
-		hashStr[20:32],
-	)
-}
-
-// TokenResponse represents the response from Twitter's token endpoint
-type TokenResponse struct {
-	AccessToken string `json:"access_token"`
-	TokenType   string `json:"token_type"`
-	ExpiresIn   int    `json:"expires_in"`
-}
-
-// TwitterUserInfo represents user data from Twitter API
-type TwitterUserInfo struct {
-	ID               string `json:"id"`
-	Username         string `json:"username"`
-	Name             string `json:"name"`
-	Verified         bool   `json:"verified"`
-	ProfileImageURL  string `json:"profile_image_url"`
+// generateUserID creates a deterministic, RFC 4122-compliant UUIDv5 from a
+// (provider, providerUserID) pair, so the same external identity always maps
+// to the same local UUID, regardless of which provider it came from.
+func (h *AuthHandler) generateUserID(provider, providerUserID string) string {
+	id, err := utils.NewV5(utils.NamespaceTimelineUser, provider+":"+providerUserID)
+	if err != nil {
+		// NamespaceTimelineUser is a compile-time constant; a failure here
+		// means it was edited into something malformed.
+		panic(fmt.Sprintf("generateUserID: %v", err))
+	}
+	return id
 }
 
-// exchangeCodeForAccessToken exchanges an OAuth code for an access token
-func exchangeCodeForAccessToken(code, codeVerifier string) (*TokenResponse, error) {
-	clientID := os.Getenv("TWITTER_CLIENT_ID")
-	clientSecret := os.Getenv("TWITTER_CLIENT_SECRET")
-	redirectURI := os.Getenv("TWITTER_REDIRECT_URI")
-
-	if clientID == "" || clientSecret == "" || redirectURI == "" {
-		return nil, fmt.Errorf("missing Twitter OAuth credentials")
-	}
+// upsertIdentityUser creates or updates the local user record for an external
+// identity and links it via user_identities, so the same Timelines user can
+// have one row per linked provider (Twitter, Google, GitHub, Apple, ...).
+func (h *AuthHandler) upsertIdentityUser(provider string, ext *auth.ExternalUser, token *auth.Token) (*models.User, error) {
+	userID := h.generateUserID(provider, ext.ProviderUserID)
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	var existingUser models.User
+	err := h.database.QueryRow(
+		`SELECT u.id, u.x_user_id, u.username, u.display_name, u.avatar_url, u.is_twitter_verified
+		 FROM users u JOIN user_identities i ON i.user_id = u.id
+		 WHERE i.provider = $1 AND i.provider_user_id = $2`,
+		provider, ext.ProviderUserID,
+	).Scan(
+		&existingUser.ID,
+		&existingUser.XUserID,
+		&existingUser.Username,
+		&existingUser.DisplayName,
+		&existingUser.AvatarURL,
+		&existingUser.IsTwitterVerified,
+	)
 
-	// Prepare token request
-	data := url.Values{}
-	data.Set("grant_type", "authorization_code")
-	data.Set("code", code)
-	data.Set("redirect_uri", redirectURI)
-	if codeVerifier != "" {
-		data.Set("code_verifier", codeVerifier)
-	}
+	var user models.User
 
-	req, err := http.NewRequest("POST", "https://x.com/2/oauth2/token", strings.NewReader(data.Encode()))
-	if err != nil {
-		return nil, err
-	}
+	if err == sql.ErrNoRows {
+		user = models.User{
+			ID:                userID,
+			Username:          &ext.Username,
+			DisplayName:       &ext.DisplayName,
+			AvatarURL:         &ext.AvatarURL,
+			IsActive:          true,
+			IsTwitterVerified: provider == "twitter" && ext.Verified,
+			CreatedAt:         time.Now(),
+		}
+		if provider == "twitter" {
+			xUserID := ext.ProviderUserID
+			user.XUserID = &xUserID
+		}
 
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	req.SetBasicAuth(clientID, clientSecret)
+		_, err := h.database.Exec(
+			`INSERT INTO users (id, x_user_id, username, display_name, avatar_url, is_active, is_twitter_verified, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			user.ID, user.XUserID, user.Username, user.DisplayName,
+			user.AvatarURL, user.IsActive, user.IsTwitterVerified, user.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	} else {
+		user = existingUser
+		user.Username = &ext.Username
+		user.DisplayName = &ext.DisplayName
+		user.AvatarURL = &ext.AvatarURL
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
+		_, err := h.database.Exec(
+			`UPDATE users SET username = $1, display_name = $2, avatar_url = $3, last_login_at = $4 WHERE id = $5`,
+			user.Username, user.DisplayName, user.AvatarURL, time.Now(), user.ID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update user: %w", err)
+		}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		errMsg := fmt.Sprintf("Twitter token exchange failed: %d - %s", resp.StatusCode, string(body))
-		log.Printf("ERROR: %s", errMsg)
-		return nil, fmt.Errorf(errMsg)
-	}
+	var expiresAt *time.Time
+	var accessToken, refreshToken string
+	var scopes []string
+	if token != nil {
+		expiresAt = token.ExpiresAt
+		scopes = token.Scopes
 
-	var tokenResp TokenResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
-		return nil, err
+		accessToken, err = auth.EncryptToken(token.AccessToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt access token: %w", err)
+		}
+		refreshToken, err = auth.EncryptToken(token.RefreshToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt refresh token: %w", err)
+		}
 	}
 
-	return &tokenResp, nil
-}
-
-// getUserInfoFromTwitter fetches user info from Twitter API v2
-func getUserInfoFromTwitter(accessToken string) (*TwitterUserInfo, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	req, err := http.NewRequest("GET", "https://api.twitter.com/2/users/me?user.fields=verified,profile_image_url", nil)
+	_, err = h.database.Exec(
+		`INSERT INTO user_identities (user_id, provider, provider_user_id, access_token, refresh_token, expires_at, scopes)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 ON CONFLICT (provider, provider_user_id) DO UPDATE SET
+		   access_token = EXCLUDED.access_token, refresh_token = EXCLUDED.refresh_token,
+		   expires_at = EXCLUDED.expires_at, scopes = EXCLUDED.scopes`,
+		user.ID, provider, ext.ProviderUserID, accessToken, refreshToken, expiresAt, pq.Array(scopes),
+	)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to link identity: %w", err)
 	}
 
-	req.Header.Add("Authorization", "Bearer "+accessToken)
+	return &user, nil
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
+// authResponseFromUser builds an AuthResponse for a user with the given session token.
+func authResponseFromUser(user *models.User, sessionToken string) AuthResponse {
+	username := ""
+	displayName := ""
+	avatarURL := ""
+	if user.Username != nil {
+		username = *user.Username
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Twitter API error: %d - %s", resp.StatusCode, string(body))
+	if user.DisplayName != nil {
+		displayName = *user.DisplayName
 	}
-
-	var twitterResp struct {
-		Data TwitterUserInfo `json:"data"`
+	if user.AvatarURL != nil {
+		avatarURL = *user.AvatarURL
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&twitterResp); err != nil {
-		return nil, err
+	return AuthResponse{
+		UserID:       user.ID,
+		Username:     username,
+		DisplayName:  displayName,
+		AvatarURL:    avatarURL,
+		IsVerified:   user.IsTwitterVerified,
+		SessionToken: sessionToken,
 	}
-
-	return &twitterResp.Data, nil
 }
+
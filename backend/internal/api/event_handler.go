@@ -2,11 +2,17 @@ package api
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 	"github.com/timeline/backend/internal/db"
+	"github.com/timeline/backend/internal/middleware"
 	"github.com/timeline/backend/internal/models"
 	"github.com/timeline/backend/internal/utils"
 )
@@ -15,11 +21,19 @@ import (
 type EventHandler struct {
 	repo *db.EventRepository
 	db   *db.DB
+	hub  *EventHub
 }
 
 // NewEventHandler creates a new event handler
 func NewEventHandler(repo *db.EventRepository, database *db.DB) *EventHandler {
-	return &EventHandler{repo: repo, db: database}
+	return &EventHandler{repo: repo, db: database, hub: NewEventHub()}
+}
+
+// Hub returns the handler's EventHub, so other subscribers (e.g. the
+// webhooks feature in internal/features) can fan out the same
+// created/updated/deleted notifications StreamEvents sends to SSE clients.
+func (h *EventHandler) Hub() *EventHub {
+	return h.hub
 }
 
 // CreateEvent handles POST /api/events
@@ -64,6 +78,8 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 		DiscussionCount: 0,
 	}
 
+	h.hub.Publish("created", response)
+
 	c.JSON(http.StatusCreated, response)
 }
 
@@ -123,8 +139,47 @@ func (h *EventHandler) ListEvents(c *gin.Context) {
 		params.Limit = 100
 	}
 
-	events, err := h.repo.List(params)
+	selector := "all"
+	if params.Selector != nil && *params.Selector != "" {
+		selector = *params.Selector
+	}
+
+	var events []models.Event
+	var err error
+
+	switch selector {
+	case "all":
+		events, err = h.repo.List(params)
+	case "mine", "voted", "sourced_by_me":
+		authUser := middleware.GetAuthUser(c)
+		if authUser == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required for selector=" + selector})
+			return
+		}
+		switch selector {
+		case "mine":
+			events, err = h.repo.ListForUser(authUser.ID, params)
+		case "voted":
+			events, err = h.repo.ListVotedByUser(authUser.ID, params)
+		case "sourced_by_me":
+			events, err = h.repo.ListSourcedByUser(authUser.ID, params)
+		}
+	case "related_to":
+		if params.RelatedTo == nil || *params.RelatedTo == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "selector=related_to requires related_to=<event_id>"})
+			return
+		}
+		events, err = h.repo.ListRelatedTo(*params.RelatedTo, params)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown selector: " + selector})
+		return
+	}
+
 	if err != nil {
+		if strings.Contains(err.Error(), "invalid after cursor") || strings.Contains(err.Error(), "invalid before cursor") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list events", "details": err.Error()})
 		return
 	}
@@ -171,9 +226,23 @@ func (h *EventHandler) ListEvents(c *gin.Context) {
 		}
 	}
 
+	isFirstPage := (params.After == nil || *params.After == "") && (params.Before == nil || *params.Before == "")
+
+	var afterCursor, beforeCursor string
+	if len(events) > 0 {
+		last := events[len(events)-1]
+		afterCursor = db.EncodeCursor(last.UnixSeconds, last.UnixNanos, last.ID)
+		if !isFirstPage {
+			first := events[0]
+			beforeCursor = db.EncodeCursor(first.UnixSeconds, first.UnixNanos, first.ID)
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"events": responses,
 		"count":  len(responses),
+		"after":  afterCursor,
+		"before": beforeCursor,
 	})
 }
 
@@ -203,6 +272,8 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 		DiscussionCount: 0,
 	}
 
+	h.hub.Publish("updated", response)
+
 	c.JSON(http.StatusOK, response)
 }
 
@@ -212,15 +283,157 @@ func (h *EventHandler) DeleteEvent(c *gin.Context) {
 
 	// TODO: Check authorization
 
-	err := h.repo.Delete(id)
+	event, err := h.repo.GetByID(id)
 	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		return
+	}
+
+	if err := h.repo.Delete(id); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete event"})
 		return
 	}
 
+	h.hub.Publish("deleted", models.EventResponse{
+		Event:         *event,
+		FormattedTime: utils.FormatTimelineForDisplay(event.UnixSeconds, event.UnixNanos),
+	})
+
 	c.JSON(http.StatusOK, gin.H{"message": "Event deleted successfully"})
 }
 
+// GetEventHistory handles GET /api/events/:id/history, returning the
+// event's revisions (most recent first) for building an edit-history view.
+func (h *EventHandler) GetEventHistory(c *gin.Context) {
+	id := c.Param("id")
+
+	history, err := h.repo.GetHistory(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get event history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"history": history,
+		"count":   len(history),
+	})
+}
+
+// RevertEventRequest is the body of POST /api/events/:id/revert.
+type RevertEventRequest struct {
+	Revision int `json:"revision" binding:"required"`
+}
+
+// RevertEvent handles POST /api/events/:id/revert, restoring the event to
+// the state it was in as of the given revision (see
+// EventRepository.Revert for what "restoring" can and can't undo).
+func (h *EventHandler) RevertEvent(c *gin.Context) {
+	id := c.Param("id")
+
+	var req RevertEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	event, err := h.repo.Revert(id, req.Revision)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revert event", "details": err.Error()})
+		return
+	}
+
+	response := models.EventResponse{
+		Event:         *event,
+		FormattedTime: utils.FormatTimelineForDisplay(event.UnixSeconds, event.UnixNanos),
+	}
+
+	h.hub.Publish("updated", response)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// sseHeartbeatInterval is how often a comment-only keepalive is sent so
+// intermediate proxies don't time out an idle connection.
+const sseHeartbeatInterval = 15 * time.Second
+
+// StreamEvents handles GET /api/events/stream
+// Upgrades to a Server-Sent Events connection and pushes created/updated/deleted
+// events matching the same filters as ListEvents. Supports Last-Event-ID so a
+// reconnecting client can replay anything it missed while disconnected.
+func (h *EventHandler) StreamEvents(c *gin.Context) {
+	var params models.EventQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ch, unsubscribe := h.hub.Subscribe(params)
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		replay, complete := h.hub.Since(lastEventID, params)
+		if !complete {
+			// The in-memory buffer was evicted past lastEventID (or this is a
+			// fresh process after a restart) - backfill from the database
+			// using the same keyset cursor ListEvents uses, since what
+			// happened is unknowable from memory alone.
+			backfillParams := params
+			backfillParams.After = &lastEventID
+			missed, err := h.repo.List(backfillParams)
+			if err != nil {
+				log.Printf("Warning: failed to backfill missed stream events: %v", err)
+			}
+			for _, event := range missed {
+				response := models.EventResponse{
+					Event:         event,
+					FormattedTime: utils.FormatTimelineForDisplay(event.UnixSeconds, event.UnixNanos),
+				}
+				writeSSEMessage(c, EventStreamMessage{
+					ID:    db.EncodeCursor(event.UnixSeconds, event.UnixNanos, event.ID),
+					Type:  "sync",
+					Event: response,
+				})
+			}
+		}
+		for _, msg := range replay {
+			writeSSEMessage(c, msg)
+		}
+		c.Writer.Flush()
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			writeSSEMessage(c, msg)
+			c.Writer.Flush()
+		}
+	}
+}
+
+func writeSSEMessage(c *gin.Context, msg EventStreamMessage) {
+	payload, err := json.Marshal(msg.Event)
+	if err != nil {
+		return
+	}
+	fmt.Fprint(c.Writer, FormatSSE(msg, payload))
+}
+
 // GetZoomPresets handles GET /api/zoom-presets
 func (h *EventHandler) GetZoomPresets(c *gin.Context) {
 	presets, err := h.repo.GetZoomPresets()
@@ -304,3 +517,347 @@ func (h *EventHandler) GetCategoriesTree(c *gin.Context) {
 		"categories": tree,
 	})
 }
+
+// GetCategoryPath handles GET /api/categories/:id/path
+// Returns the breadcrumb from the root category down to :id, for faceted navigation.
+func (h *EventHandler) GetCategoryPath(c *gin.Context) {
+	categoryID := c.Param("id")
+
+	path, err := utils.GetCategoryPath(categoryID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get category path", "details": err.Error()})
+		return
+	}
+	if len(path) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"path": path})
+}
+
+// ReconcileEstimateRequest is a single source's date estimate for an event
+type ReconcileEstimateRequest struct {
+	TimelineSeconds  decimal.Decimal  `json:"timeline_seconds" binding:"required"`
+	UncertaintyRange *decimal.Decimal `json:"uncertainty_range,omitempty"`
+	Correlated       bool             `json:"correlated"`
+	SourceID         *string          `json:"source_id,omitempty"`
+}
+
+// ReconcileRequest carries the multi-source estimates to combine for an event
+type ReconcileRequest struct {
+	Estimates []ReconcileEstimateRequest `json:"estimates" binding:"required,min=1"`
+}
+
+// ReconcileEvent handles POST /api/events/:id/reconcile
+// Combines multiple source date estimates into a single best-estimate timeline
+// point (inverse-variance-weighted mean) and persists it on the event.
+func (h *EventHandler) ReconcileEvent(c *gin.Context) {
+	id := c.Param("id")
+
+	var req ReconcileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	event, err := h.repo.GetByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		return
+	}
+
+	estimates := make([]utils.TimelineTime, len(req.Estimates))
+	for i, e := range req.Estimates {
+		estimates[i] = utils.TimelineTime{
+			Seconds:          e.TimelineSeconds,
+			Precision:        event.PrecisionLevel,
+			UncertaintyRange: e.UncertaintyRange,
+			Correlated:       e.Correlated,
+		}
+	}
+
+	merged := estimates[0].Merge(estimates[1:]...)
+
+	var uncertaintySeconds *int64
+	if merged.UncertaintyRange != nil {
+		v := merged.UncertaintyRange.IntPart()
+		uncertaintySeconds = &v
+	}
+
+	unixSeconds, err := utils.TimelineToUnix(merged.Seconds)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Reconciled estimate is outside representable range", "details": err.Error()})
+		return
+	}
+
+	updated, err := h.repo.Update(id, models.UpdateEventRequest{
+		UnixSeconds:      &unixSeconds,
+		UncertaintyRange: uncertaintySeconds,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to persist reconciled estimate", "details": err.Error()})
+		return
+	}
+
+	response := models.EventResponse{
+		Event:         *updated,
+		FormattedTime: utils.FormatTimelineForDisplay(merged.Seconds),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// maxBatchItems caps the total number of create/update/delete entries accepted
+// by a single POST /api/events/batch call, so an importer can't turn one
+// request into an unbounded transaction.
+const maxBatchItems = 500
+
+// BatchUpdateItem is an UpdateEventRequest addressed at a specific event.
+type BatchUpdateItem struct {
+	ID string `json:"id" binding:"required"`
+	models.UpdateEventRequest
+}
+
+// BatchEventsRequest is the body of POST /api/events/batch.
+type BatchEventsRequest struct {
+	Create []models.CreateEventRequest `json:"create,omitempty"`
+	Update []BatchUpdateItem           `json:"update,omitempty"`
+	Delete []string                    `json:"delete,omitempty"`
+}
+
+// BatchResultItem reports the outcome of a single operation within a batch.
+type BatchResultItem struct {
+	Op     string               `json:"op"`
+	Index  int                  `json:"index"`
+	Status int                  `json:"status"`
+	Event  *models.EventResponse `json:"event,omitempty"`
+	Error  string               `json:"error,omitempty"`
+}
+
+// BatchEvents handles POST /api/events/batch
+//
+// Executes a mix of creates, updates and deletes as one transaction. By
+// default each item runs behind its own savepoint, so one bad item is rolled
+// back without discarding the rest of the batch (partial-success semantics).
+// Pass ?atomic=true to make the whole batch all-or-nothing instead.
+func (h *EventHandler) BatchEvents(c *gin.Context) {
+	var req BatchEventsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	total := len(req.Create) + len(req.Update) + len(req.Delete)
+	if total == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Batch must contain at least one create, update, or delete"})
+		return
+	}
+	if total > maxBatchItems {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Batch exceeds maximum of %d items", maxBatchItems)})
+		return
+	}
+
+	atomic := c.Query("atomic") == "true"
+
+	var userID *string
+	if authUser, exists := c.Get("user"); exists && authUser != nil {
+		user := authUser.(*models.User)
+		userID = &user.ID
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start batch transaction"})
+		return
+	}
+
+	results := make([]BatchResultItem, 0, total)
+	failed := false
+
+	runItem := func(savepoint string, run func() (*models.Event, error)) (*models.Event, error) {
+		if atomic {
+			return run()
+		}
+
+		if _, err := tx.Exec(fmt.Sprintf("SAVEPOINT %s", savepoint)); err != nil {
+			return nil, err
+		}
+		event, err := run()
+		if err != nil {
+			tx.Exec(fmt.Sprintf("ROLLBACK TO SAVEPOINT %s", savepoint))
+			return nil, err
+		}
+		tx.Exec(fmt.Sprintf("RELEASE SAVEPOINT %s", savepoint))
+		return event, nil
+	}
+
+itemLoop:
+	for i, item := range req.Create {
+		if item.Category != nil && *item.Category != "" && !utils.IsValidCategory(*item.Category) {
+			results = append(results, BatchResultItem{Op: "create", Index: i, Status: http.StatusBadRequest, Error: "Invalid category"})
+			failed = true
+			if atomic {
+				break itemLoop
+			}
+			continue
+		}
+
+		event, err := runItem(fmt.Sprintf("batch_create_%d", i), func() (*models.Event, error) {
+			return h.repo.CreateTx(tx, item, userID)
+		})
+		if err != nil {
+			results = append(results, BatchResultItem{Op: "create", Index: i, Status: http.StatusInternalServerError, Error: err.Error()})
+			failed = true
+			if atomic {
+				break itemLoop
+			}
+			continue
+		}
+
+		response := models.EventResponse{Event: *event, FormattedTime: utils.FormatTimelineForDisplay(event.UnixSeconds, event.UnixNanos)}
+		results = append(results, BatchResultItem{Op: "create", Index: i, Status: http.StatusCreated, Event: &response})
+	}
+
+	if !failed || !atomic {
+		for i, item := range req.Update {
+			event, err := runItem(fmt.Sprintf("batch_update_%d", i), func() (*models.Event, error) {
+				return h.repo.UpdateTx(tx, item.ID, item.UpdateEventRequest)
+			})
+			if err != nil {
+				results = append(results, BatchResultItem{Op: "update", Index: i, Status: http.StatusInternalServerError, Error: err.Error()})
+				failed = true
+				if atomic {
+					break
+				}
+				continue
+			}
+
+			response := models.EventResponse{Event: *event, FormattedTime: utils.FormatTimelineForDisplay(event.UnixSeconds, event.UnixNanos)}
+			results = append(results, BatchResultItem{Op: "update", Index: i, Status: http.StatusOK, Event: &response})
+		}
+	}
+
+	if !failed || !atomic {
+		for i, id := range req.Delete {
+			_, err := runItem(fmt.Sprintf("batch_delete_%d", i), func() (*models.Event, error) {
+				return nil, h.repo.DeleteTx(tx, id)
+			})
+			if err != nil {
+				results = append(results, BatchResultItem{Op: "delete", Index: i, Status: http.StatusInternalServerError, Error: err.Error()})
+				failed = true
+				if atomic {
+					break
+				}
+				continue
+			}
+
+			results = append(results, BatchResultItem{Op: "delete", Index: i, Status: http.StatusOK})
+		}
+	}
+
+	if atomic && failed {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Batch failed and was rolled back in full (atomic=true)", "results": results})
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit batch"})
+		return
+	}
+
+	status := http.StatusOK
+	if failed {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, gin.H{"results": results})
+}
+
+// maxBulkIngestItems caps a single bulk ingest request. Unlike BatchEvents
+// (savepoint-per-item, interactive-sized), this path is meant for
+// thousands-of-rows imports via pq.CopyIn, so the ceiling is much higher.
+const maxBulkIngestItems = 50000
+
+// BulkIngestRequest is the body of POST /api/events/bulk and
+// POST /api/events/bulk/upsert.
+type BulkIngestRequest struct {
+	Events []models.CreateEventRequest `json:"events" binding:"required"`
+}
+
+// BulkIngestResponse reports what BulkCreate/BulkUpsert did: the events that
+// made it in, and any rows that were rejected before reaching Postgres.
+type BulkIngestResponse struct {
+	Created int                `json:"created"`
+	Events  []models.Event     `json:"events"`
+	Errors  []db.BulkItemError `json:"errors,omitempty"`
+}
+
+// BulkCreateEvents handles POST /api/events/bulk, streaming events into
+// Postgres with pq.CopyIn for large one-shot imports (Wikidata dumps,
+// historical CSVs). Prefer POST /api/events/batch for interactive,
+// human-sized writes -- this path trades per-item transactional isolation
+// for throughput.
+func (h *EventHandler) BulkCreateEvents(c *gin.Context) {
+	var req BulkIngestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.Events) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "events must contain at least one item"})
+		return
+	}
+	if len(req.Events) > maxBulkIngestItems {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("bulk ingest exceeds maximum of %d events", maxBulkIngestItems)})
+		return
+	}
+
+	var userID *string
+	if authUser, exists := c.Get("user"); exists && authUser != nil {
+		user := authUser.(*models.User)
+		userID = &user.ID
+	}
+
+	created, itemErrors, err := h.repo.BulkCreate(req.Events, userID, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Bulk create failed", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, BulkIngestResponse{Created: len(created), Events: created, Errors: itemErrors})
+}
+
+// BulkUpsertEvents handles POST /api/events/bulk/upsert, the idempotent
+// counterpart to BulkCreateEvents: events are matched on external_id, so
+// re-running the same import updates existing rows instead of duplicating
+// them.
+func (h *EventHandler) BulkUpsertEvents(c *gin.Context) {
+	var req BulkIngestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.Events) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "events must contain at least one item"})
+		return
+	}
+	if len(req.Events) > maxBulkIngestItems {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("bulk ingest exceeds maximum of %d events", maxBulkIngestItems)})
+		return
+	}
+
+	var userID *string
+	if authUser, exists := c.Get("user"); exists && authUser != nil {
+		user := authUser.(*models.User)
+		userID = &user.ID
+	}
+
+	upserted, itemErrors, err := h.repo.BulkUpsert(req.Events, userID, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Bulk upsert failed", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, BulkIngestResponse{Created: len(upserted), Events: upserted, Errors: itemErrors})
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/timeline/backend/internal/db"
+	"github.com/timeline/backend/internal/models"
+)
+
+// streamBufferSize bounds each subscriber's backlog; once full, the oldest
+// pending message is dropped so a slow consumer can't back-pressure publishers.
+const streamBufferSize = 64
+
+// EventStreamMessage is a single lifecycle notification pushed to SSE subscribers.
+// ID is the same keyset cursor ListEvents uses (db.EncodeCursor over the
+// triggering event's unix_seconds/unix_nanos/id), not a process-local
+// counter, so it stays meaningful to a reconnecting client across restarts.
+type EventStreamMessage struct {
+	ID    string               `json:"-"`
+	Type  string               `json:"type"` // "created", "updated", "deleted", or "sync" (see Since)
+	Event models.EventResponse `json:"event"`
+}
+
+type streamSubscriber struct {
+	ch     chan EventStreamMessage
+	params models.EventQueryParams
+}
+
+// replayBufferSize bounds how far back a reconnecting client (via Last-Event-ID)
+// can replay from the in-memory history before it must instead re-fetch via List.
+const replayBufferSize = 500
+
+// EventHub is an in-process pub/sub hub that fans out event lifecycle
+// notifications to connected SSE clients.
+type EventHub struct {
+	mu          sync.Mutex
+	subscribers map[int]*streamSubscriber
+	nextID      int
+	history     []EventStreamMessage
+}
+
+// NewEventHub creates an empty hub.
+func NewEventHub() *EventHub {
+	return &EventHub{subscribers: make(map[int]*streamSubscriber)}
+}
+
+// Subscribe registers a new subscriber matching params and returns its channel
+// plus an unsubscribe function the caller must defer.
+func (h *EventHub) Subscribe(params models.EventQueryParams) (<-chan EventStreamMessage, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := h.nextID
+	h.nextID++
+
+	sub := &streamSubscriber{
+		ch:     make(chan EventStreamMessage, streamBufferSize),
+		params: params,
+	}
+	h.subscribers[id] = sub
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subscribers, id)
+		close(sub.ch)
+	}
+
+	return sub.ch, unsubscribe
+}
+
+// Publish fans msgType/event out to every subscriber whose filter matches,
+// dropping the oldest buffered message for any subscriber whose channel is full.
+func (h *EventHub) Publish(msgType string, event models.EventResponse) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := db.EncodeCursor(event.UnixSeconds, event.UnixNanos, event.ID)
+	msg := EventStreamMessage{ID: id, Type: msgType, Event: event}
+
+	h.history = append(h.history, msg)
+	if len(h.history) > replayBufferSize {
+		h.history = h.history[len(h.history)-replayBufferSize:]
+	}
+
+	for _, sub := range h.subscribers {
+		if !matchesStreamFilter(sub.params, event) {
+			continue
+		}
+
+		select {
+		case sub.ch <- msg:
+		default:
+			// Buffer full: drop the oldest message to make room, then retry once.
+			select {
+			case <-sub.ch:
+			default:
+			}
+			select {
+			case sub.ch <- msg:
+			default:
+			}
+		}
+	}
+}
+
+// matchesStreamFilter reapplies EventQueryParams' start/end/category/min_importance
+// filters to a single event, since the hub fans out in-process rather than via SQL.
+func matchesStreamFilter(params models.EventQueryParams, event models.EventResponse) bool {
+	if params.StartSeconds != nil && event.UnixSeconds < *params.StartSeconds {
+		return false
+	}
+	if params.EndSeconds != nil && event.UnixSeconds > *params.EndSeconds {
+		return false
+	}
+	if params.Category != nil && (event.Category == nil || *event.Category != *params.Category) {
+		return false
+	}
+	if params.MinImportance != nil && event.ImportanceScore < *params.MinImportance {
+		return false
+	}
+	return true
+}
+
+// Since replays messages published after lastID (an event-derived keyset
+// cursor, see db.EncodeCursor), matching params, from the in-memory history
+// buffer. complete is false whenever the buffer can't prove it holds every
+// message since lastID — an empty buffer (fresh process) or a lastID older
+// than the oldest retained entry both mean messages may have been evicted or
+// missed entirely; the caller must then fall back to EventRepository.List to
+// backfill from the database.
+func (h *EventHub) Since(lastID string, params models.EventQueryParams) (replay []EventStreamMessage, complete bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	lastSeconds, lastNanos, lastEventID, err := db.DecodeCursor(lastID)
+	if err != nil || len(h.history) == 0 {
+		return nil, false
+	}
+
+	oldest := h.history[0].Event
+	if cursorLess(lastSeconds, lastNanos, lastEventID, oldest.UnixSeconds, oldest.UnixNanos, oldest.ID) {
+		return nil, false
+	}
+
+	for _, msg := range h.history {
+		e := msg.Event
+		if cursorLess(lastSeconds, lastNanos, lastEventID, e.UnixSeconds, e.UnixNanos, e.ID) && matchesStreamFilter(params, e) {
+			replay = append(replay, msg)
+		}
+	}
+	return replay, true
+}
+
+// cursorLess reports whether (aSeconds, aNanos, aID) sorts strictly before
+// (bSeconds, bNanos, bID) under the same ordering List uses for keyset
+// pagination.
+func cursorLess(aSeconds int64, aNanos int32, aID string, bSeconds int64, bNanos int32, bID string) bool {
+	if aSeconds != bSeconds {
+		return aSeconds < bSeconds
+	}
+	if aNanos != bNanos {
+		return aNanos < bNanos
+	}
+	return aID < bID
+}
+
+// FormatSSE renders a message as an SSE "event"/"id"/"data" frame.
+func FormatSSE(msg EventStreamMessage, payload []byte) string {
+	return fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", msg.ID, msg.Type, payload)
+}
@@ -1,22 +1,37 @@
 package api
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 	"github.com/timeline/backend/internal/db"
+	"github.com/timeline/backend/internal/enrich"
 )
 
 // GeolocationHandler handles geolocation-related HTTP requests
 type GeolocationHandler struct {
-	db *db.DB
+	db     *db.DB
+	enrich *enrich.Worker
 }
 
 // NewGeolocationHandler creates a new geolocation handler
 func NewGeolocationHandler(database *db.DB) *GeolocationHandler {
-	return &GeolocationHandler{db: database}
+	cache := enrich.NewCache(database)
+	worker := enrich.NewWorker(
+		cache,
+		enrich.NewNaturalEarthGeocoder(database),
+		enrich.NewNominatimGeocoder("https://nominatim.openstreetmap.org", "timelines-backend"),
+	)
+
+	return &GeolocationHandler{db: database, enrich: worker}
 }
 
 // EventLocation represents a location for an event
@@ -30,6 +45,10 @@ type EventLocation struct {
 	ConfidenceScore  int             `json:"confidence_score"`
 	IsPrimary        bool            `json:"is_primary"`
 	SourceID         string          `json:"source_id"`
+	CountryCode      string          `json:"country_code,omitempty"`
+	Admin1Code       string          `json:"admin1_code,omitempty"`
+	Admin2Code       string          `json:"admin2_code,omitempty"`
+	RollupGeoJSON    json.RawMessage `json:"rollup_geojson,omitempty"`
 }
 
 // GeoJSONFeature represents a GeoJSON feature
@@ -46,8 +65,51 @@ type GeoJSONFeatureCollection struct {
 	Features []GeoJSONFeature `json:"features"`
 }
 
-// GetLocationsGeoJSON returns all event locations as GeoJSON
+// parseBBox parses a "minLon,minLat,maxLon,maxLat" query param
+func parseBBox(raw string) (minLon, minLat, maxLon, maxLat float64, ok bool) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return 0, 0, 0, 0, false
+	}
+
+	vals := make([]float64, 4)
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return 0, 0, 0, 0, false
+		}
+		vals[i] = v
+	}
+
+	return vals[0], vals[1], vals[2], vals[3], true
+}
+
+// parseAdminFilter parses a "level:value" admin filter, e.g. "country:FR"
+func parseAdminFilter(raw string) (level, value string, ok bool) {
+	parts := strings.SplitN(raw, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// adminColumnForLevel maps an admin filter level to its indexed column
+func adminColumnForLevel(level string) (string, bool) {
+	switch level {
+	case "country":
+		return "country_code", true
+	case "admin1":
+		return "admin1_code", true
+	case "admin2":
+		return "admin2_code", true
+	default:
+		return "", false
+	}
+}
+
+// GetLocationsGeoJSON returns event locations as GeoJSON
 // GET /api/events/locations/geojson
+// Optional filters: ?bbox=minLon,minLat,maxLon,maxLat and ?categories=cat1,cat2
 func (h *GeolocationHandler) GetLocationsGeoJSON(c *gin.Context) {
 	query := `
 	SELECT
@@ -62,11 +124,55 @@ func (h *GeolocationHandler) GetLocationsGeoJSON(c *gin.Context) {
 		el.source_id
 	FROM event_locations el
 	JOIN events e ON el.event_id = e.id
-	WHERE el.location_point IS NOT NULL OR el.location_polygon IS NOT NULL
-	ORDER BY el.is_primary DESC, el.confidence_score DESC
+	WHERE (el.location_point IS NOT NULL OR el.location_polygon IS NOT NULL)
 	`
 
-	rows, err := h.db.Query(query)
+	args := []interface{}{}
+	argCount := 1
+
+	if bbox := c.Query("bbox"); bbox != "" {
+		minLon, minLat, maxLon, maxLat, ok := parseBBox(bbox)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bbox, expected minLon,minLat,maxLon,maxLat"})
+			return
+		}
+		query += fmt.Sprintf(` AND ST_Intersects(
+			COALESCE(el.location_point, el.location_polygon),
+			ST_MakeEnvelope($%d, $%d, $%d, $%d, 4326)
+		)`, argCount, argCount+1, argCount+2, argCount+3)
+		args = append(args, minLon, minLat, maxLon, maxLat)
+		argCount += 4
+	}
+
+	if categories := c.Query("categories"); categories != "" {
+		cats := strings.Split(categories, ",")
+		for i, cat := range cats {
+			cats[i] = strings.TrimSpace(cat)
+		}
+		query += fmt.Sprintf(" AND e.category = ANY($%d)", argCount)
+		args = append(args, pq.Array(cats))
+		argCount++
+	}
+
+	if admin := c.Query("admin"); admin != "" {
+		level, value, ok := parseAdminFilter(admin)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid admin filter, expected level:value e.g. country:FR"})
+			return
+		}
+		column, ok := adminColumnForLevel(level)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown admin level, expected one of country, admin1, admin2"})
+			return
+		}
+		query += fmt.Sprintf(" AND el.%s = $%d", column, argCount)
+		args = append(args, value)
+		argCount++
+	}
+
+	query += " ORDER BY el.is_primary DESC, el.confidence_score DESC"
+
+	rows, err := h.db.Query(query, args...)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query locations"})
 		return
@@ -273,3 +379,107 @@ func (h *GeolocationHandler) UpdateEventLocation(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Location updated successfully", "location_id": result})
 }
+
+// GetLocationsTile returns event locations for a single XYZ tile as a Mapbox Vector Tile
+// GET /api/events/locations/tiles/:z/:x/:y.mvt
+func (h *GeolocationHandler) GetLocationsTile(c *gin.Context) {
+	z, errZ := strconv.Atoi(c.Param("z"))
+	x, errX := strconv.Atoi(c.Param("x"))
+	yParam := strings.TrimSuffix(c.Param("y.mvt"), ".mvt")
+	y, errY := strconv.Atoi(yParam)
+
+	if errZ != nil || errX != nil || errY != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tile coordinates"})
+		return
+	}
+
+	query := `
+	WITH bounds AS (
+		SELECT ST_TileEnvelope($1, $2, $3) AS geom
+	),
+	mvtgeom AS (
+		SELECT
+			el.id,
+			el.event_id,
+			e.title AS event_title,
+			e.category,
+			el.confidence_score,
+			el.is_primary,
+			ST_AsMVTGeom(
+				ST_Transform(COALESCE(el.location_point, el.location_polygon), 3857),
+				bounds.geom
+			) AS geom
+		FROM event_locations el
+		JOIN events e ON el.event_id = e.id
+		CROSS JOIN bounds
+		WHERE (el.location_point IS NOT NULL OR el.location_polygon IS NOT NULL)
+			AND ST_Intersects(COALESCE(el.location_point, el.location_polygon), ST_Transform(bounds.geom, 4326))
+	)
+	SELECT ST_AsMVT(mvtgeom.*, 'event_locations') FROM mvtgeom
+	`
+
+	var tile []byte
+	err := h.db.QueryRow(query, z, x, y).Scan(&tile)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate tile"})
+		return
+	}
+
+	sum := sha256.Sum256(tile)
+	etag := fmt.Sprintf(`"%s"`, hex.EncodeToString(sum[:])[:16])
+	if match := c.GetHeader("If-None-Match"); match != "" && match == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Header("ETag", etag)
+	c.Data(http.StatusOK, "application/vnd.mapbox-vector-tile", tile)
+}
+
+// EnrichLocation handles POST /api/events/:id/locations/:locId/enrich
+// Reverse-geocodes an event_locations row's point/polygon to fill in its
+// location name and admin boundary codes.
+func (h *GeolocationHandler) EnrichLocation(c *gin.Context) {
+	locID := c.Param("locId")
+
+	var lat, lon float64
+	query := `
+		SELECT ST_Y(ST_Centroid(COALESCE(location_point, location_polygon))),
+		       ST_X(ST_Centroid(COALESCE(location_point, location_polygon)))
+		FROM event_locations
+		WHERE id = $1
+	`
+	if err := h.db.QueryRow(query, locID).Scan(&lat, &lon); err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Location not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load location"})
+		return
+	}
+
+	result, err := h.enrich.Enrich(c.Request.Context(), lat, lon)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to enrich location", "details": err.Error()})
+		return
+	}
+
+	updateQuery := `
+		UPDATE event_locations
+		SET location_name = $1, country_code = $2, admin1_code = $3, admin2_code = $4, rollup_geojson = $5
+		WHERE id = $6
+	`
+	_, err = h.db.Exec(updateQuery, result.LocationName, result.CountryCode, result.Admin1Code, result.Admin2Code, result.RollupGeoJSON, locID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save enrichment", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"location_name": result.LocationName,
+		"country_code":  result.CountryCode,
+		"admin1_code":   result.Admin1Code,
+		"admin2_code":   result.Admin2Code,
+	})
+}
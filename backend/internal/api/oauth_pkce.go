@@ -0,0 +1,233 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// oauthStateCookieName is the signed, httpOnly cookie that carries the PKCE
+// state/verifier pair between /auth/{provider}/start and the callback, so the
+// server (not the frontend) is the source of truth for both.
+const oauthStateCookieName = "oauth_pkce_state"
+
+// oauthStateTTL bounds how long a user has to complete the provider's consent
+// screen before the stored verifier is no longer accepted.
+const oauthStateTTL = 10 * time.Minute
+
+// oauthStateCookie is the JSON payload signed into the state cookie.
+type oauthStateCookie struct {
+	Provider     string `json:"provider"`
+	State        string `json:"state"`
+	CodeVerifier string `json:"code_verifier"`
+	ExpiresAt    int64  `json:"exp"`
+}
+
+// oauthCookieSecret returns the HMAC key used to sign the PKCE state cookie,
+// mirroring the JWT_SIGNING_KEYS convention used in middleware/jwt.go.
+func oauthCookieSecret() (string, error) {
+	secret := os.Getenv("OAUTH_COOKIE_SECRET")
+	if secret == "" {
+		return "", fmt.Errorf("OAUTH_COOKIE_SECRET is not configured")
+	}
+	return secret, nil
+}
+
+// randomBase64URL returns a base64url (unpadded) encoding of n cryptographically
+// random bytes.
+func randomBase64URL(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// signOAuthStateCookie serializes and HMAC-signs a PKCE state payload for
+// storage in a cookie.
+func signOAuthStateCookie(payload oauthStateCookie) (string, error) {
+	secret, err := oauthCookieSecret()
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(body)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(encoded))
+	signature := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	return encoded + "." + signature, nil
+}
+
+// verifyOAuthStateCookie validates the signature on a cookie produced by
+// signOAuthStateCookie and returns the decoded payload.
+func verifyOAuthStateCookie(cookie string) (*oauthStateCookie, error) {
+	secret, err := oauthCookieSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	var encoded, signature string
+	for i := len(cookie) - 1; i >= 0; i-- {
+		if cookie[i] == '.' {
+			encoded, signature = cookie[:i], cookie[i+1:]
+			break
+		}
+	}
+	if encoded == "" || signature == "" {
+		return nil, fmt.Errorf("malformed state cookie")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(encoded))
+	expected := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, fmt.Errorf("invalid state cookie signature")
+	}
+
+	body, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("malformed state cookie")
+	}
+
+	var payload oauthStateCookie
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("malformed state cookie")
+	}
+
+	if time.Now().Unix() > payload.ExpiresAt {
+		return nil, fmt.Errorf("state cookie expired")
+	}
+
+	return &payload, nil
+}
+
+// constantTimeEqual compares two strings in constant time, for comparing the
+// callback's state parameter against the one stored in the session cookie.
+func constantTimeEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// StartOAuth handles GET /auth/:provider/start
+//
+// Generates a fresh PKCE code_verifier and a random CSRF state, stores both
+// server-side in a signed, httpOnly cookie, and redirects the browser to the
+// provider's authorize endpoint. This keeps the verifier out of the frontend
+// entirely, so it can't be swapped or replayed.
+func (h *AuthHandler) StartOAuth(c *gin.Context) {
+	providerName := c.Param("provider")
+	provider, ok := h.providers.Get(providerName)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown auth provider: " + providerName})
+		return
+	}
+
+	state, err := randomBase64URL(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate OAuth state"})
+		return
+	}
+
+	codeVerifier, err := randomBase64URL(96)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate PKCE verifier"})
+		return
+	}
+
+	cookieValue, err := signOAuthStateCookie(oauthStateCookie{
+		Provider:     provider.Name(),
+		State:        state,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(oauthStateTTL).Unix(),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start OAuth flow: " + err.Error()})
+		return
+	}
+
+	c.SetCookie(oauthStateCookieName, cookieValue, int(oauthStateTTL.Seconds()), "/", "", true, true)
+	c.Redirect(http.StatusFound, provider.AuthURL(state, codeVerifier))
+}
+
+// OAuthCallback handles GET /auth/:provider/callback
+//
+// Validates the callback's `state` against the signed cookie StartOAuth set,
+// exchanges `code` for a token using the verifier from that same cookie (never
+// from the request), fetches the external profile, upserts the local user and
+// its linked identity, and issues a session.
+func (h *AuthHandler) OAuthCallback(c *gin.Context) {
+	providerName := c.Param("provider")
+	provider, ok := h.providers.Get(providerName)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown auth provider: " + providerName})
+		return
+	}
+
+	code := c.Query("code")
+	state := c.Query("state")
+	if code == "" || state == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing code or state"})
+		return
+	}
+
+	cookieValue, err := c.Cookie(oauthStateCookieName)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing or expired OAuth session"})
+		return
+	}
+
+	stored, err := verifyOAuthStateCookie(cookieValue)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid OAuth session: " + err.Error()})
+		return
+	}
+
+	if stored.Provider != provider.Name() || !constantTimeEqual(state, stored.State) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "State mismatch"})
+		return
+	}
+
+	// Single-use: clear the cookie now that it's been consumed, regardless of
+	// whether the exchange below succeeds.
+	c.SetCookie(oauthStateCookieName, "", -1, "/", "", true, true)
+
+	token, err := provider.Exchange(c.Request.Context(), code, stored.CodeVerifier)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Failed to exchange code: " + err.Error()})
+		return
+	}
+
+	extUser, err := provider.FetchUser(c.Request.Context(), token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Failed to get user info: " + err.Error()})
+		return
+	}
+
+	user, err := h.upsertIdentityUser(provider.Name(), extUser, token)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	sessionToken, err := h.issueSession(user.ID, c.Request.UserAgent(), c.ClientIP(), time.Time{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, authResponseFromUser(user, sessionToken))
+}
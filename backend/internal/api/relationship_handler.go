@@ -0,0 +1,160 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/timeline/backend/internal/db"
+	"github.com/timeline/backend/internal/models"
+)
+
+// RelationshipHandler exposes typed, Matrix-/relations-style endpoints over
+// event_relationships, as a companion to EventHandler's inline
+// GetEventRelationships (kept as-is for existing integrations).
+type RelationshipHandler struct {
+	repo *db.EventRepository
+}
+
+// NewRelationshipHandler creates a new relationship handler.
+func NewRelationshipHandler(repo *db.EventRepository) *RelationshipHandler {
+	return &RelationshipHandler{repo: repo}
+}
+
+// CreateRelationshipRequest is the body of POST /api/events/:id/relations.
+type CreateRelationshipRequest struct {
+	RelatedEventID string  `json:"related_event_id" binding:"required"`
+	Type           string  `json:"relationship_type" binding:"required"`
+	Weight         *string `json:"weight,omitempty"`
+	Description    string  `json:"description,omitempty"`
+}
+
+// CreateRelationship handles POST /api/events/:id/relations, linking :id to
+// related_event_id with the given type.
+func (h *RelationshipHandler) CreateRelationship(c *gin.Context) {
+	eventID := c.Param("id")
+
+	var req CreateRelationshipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !models.RelationshipType(req.Type).IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":       "Invalid relationship_type",
+			"valid_types": models.ValidRelationshipTypes,
+		})
+		return
+	}
+
+	weight := decimal.NewFromInt(1)
+	if req.Weight != nil {
+		parsed, err := decimal.NewFromString(*req.Weight)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "weight must be a decimal number"})
+			return
+		}
+		weight = parsed
+	}
+
+	rel, err := h.repo.CreateRelationship(eventID, req.RelatedEventID, req.Type, weight, req.Description)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create relationship", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, rel)
+}
+
+// DeleteRelationship handles DELETE /api/relations/:relationshipId.
+func (h *RelationshipHandler) DeleteRelationship(c *gin.Context) {
+	id := c.Param("relationshipId")
+
+	if err := h.repo.DeleteRelationship(id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Relationship not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete relationship"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetRelationshipsByType handles GET /api/events/:id/relations/:type, a
+// paginated list of this event's relationships of exactly that type.
+func (h *RelationshipHandler) GetRelationshipsByType(c *gin.Context) {
+	eventID := c.Param("id")
+	relType := c.Param("type")
+
+	if !models.RelationshipType(relType).IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":       "Invalid relationship type",
+			"valid_types": models.ValidRelationshipTypes,
+		})
+		return
+	}
+
+	limit := 100
+	if raw := c.Query("limit"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+	offset := 0
+	if raw := c.Query("offset"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	relationships, err := h.repo.GetRelationshipsByType(eventID, relType, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get relationships"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"relationships": relationships,
+		"count":         len(relationships),
+	})
+}
+
+// GetRelatedEvents handles GET /api/events/:id/relations/:type/recursive,
+// walking event_relationships of the given type up to ?depth= hops (default
+// 1; larger values are capped by EventRepository's own maximum).
+func (h *RelationshipHandler) GetRelatedEvents(c *gin.Context) {
+	eventID := c.Param("id")
+	relType := c.Param("type")
+
+	if !models.RelationshipType(relType).IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":       "Invalid relationship type",
+			"valid_types": models.ValidRelationshipTypes,
+		})
+		return
+	}
+
+	depth := 1
+	if raw := c.Query("depth"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			depth = parsed
+		}
+	}
+
+	events, err := h.repo.GetRelatedEventsRecursive(eventID, relType, depth)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get related events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"events": events,
+		"count":  len(events),
+	})
+}
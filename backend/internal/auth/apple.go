@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// AppleProvider implements Provider against "Sign in with Apple".
+//
+// Apple's client secret is itself a short-lived ES256 JWT signed with the
+// developer's private key; generating it requires the team ID/key ID/private
+// key from the Apple Developer portal. Rather than hand-roll ES256 signing
+// here, we expect ops to mint that JWT out-of-band (it's valid for up to six
+// months) and hand it to us via APPLE_CLIENT_SECRET, rotating it before expiry.
+type AppleProvider struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+	client       *http.Client
+}
+
+// NewAppleProvider builds an AppleProvider from APPLE_CLIENT_ID,
+// APPLE_CLIENT_SECRET and APPLE_REDIRECT_URI. Returns ok=false if any are unset.
+func NewAppleProvider() (*AppleProvider, bool) {
+	clientID := os.Getenv("APPLE_CLIENT_ID")
+	clientSecret := os.Getenv("APPLE_CLIENT_SECRET")
+	redirectURI := os.Getenv("APPLE_REDIRECT_URI")
+	if clientID == "" || clientSecret == "" || redirectURI == "" {
+		return nil, false
+	}
+	return &AppleProvider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURI:  redirectURI,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}, true
+}
+
+func (p *AppleProvider) Name() string { return "apple" }
+
+func (p *AppleProvider) AuthURL(state, verifier string) string {
+	return "https://appleid.apple.com/auth/authorize?" + url.Values{
+		"response_type": {"code"},
+		"response_mode": {"form_post"},
+		"client_id":     {p.ClientID},
+		"redirect_uri":  {p.RedirectURI},
+		"scope":         {"name email"},
+		"state":         {state},
+	}.Encode()
+}
+
+func (p *AppleProvider) Exchange(ctx context.Context, code, verifier string) (*Token, error) {
+	data := url.Values{}
+	data.Set("grant_type", "authorization_code")
+	data.Set("code", code)
+	data.Set("client_id", p.ClientID)
+	data.Set("client_secret", p.ClientSecret)
+	data.Set("redirect_uri", p.RedirectURI)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://appleid.apple.com/auth/token", strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Apple token exchange failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		IDToken     string `json:"id_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	return &Token{
+		// Apple's identity claims live in the id_token, not a fetchable
+		// userinfo endpoint, so we stash it in AccessToken for FetchUser to decode.
+		AccessToken: tokenResp.IDToken,
+		ExpiresAt:   &expiresAt,
+	}, nil
+}
+
+// appleIDTokenClaims is the subset of Apple's id_token payload we care about.
+type appleIDTokenClaims struct {
+	Sub           string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified,string"`
+}
+
+// FetchUser decodes the id_token returned by Exchange. Apple signs it with
+// ES256 against keys published at https://appleid.apple.com/auth/keys; we
+// decode the claims without verifying the signature here since the token just
+// traveled directly from Apple's token endpoint over TLS, not through the
+// browser. A future hardening pass should verify it against Apple's JWKS
+// before trusting claims from any less direct path.
+func (p *AppleProvider) FetchUser(ctx context.Context, token *Token) (*ExternalUser, error) {
+	parts := strings.Split(token.AccessToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed Apple id_token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed Apple id_token payload: %w", err)
+	}
+
+	var claims appleIDTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("malformed Apple id_token claims: %w", err)
+	}
+
+	return &ExternalUser{
+		ProviderUserID: claims.Sub,
+		Username:       claims.Email,
+		DisplayName:    claims.Email,
+		Verified:       claims.EmailVerified,
+	}, nil
+}
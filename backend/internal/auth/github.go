@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GitHubProvider implements Provider against GitHub's OAuth2 endpoints.
+type GitHubProvider struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+	client       *http.Client
+}
+
+// NewGitHubProvider builds a GitHubProvider from GITHUB_CLIENT_ID,
+// GITHUB_CLIENT_SECRET and GITHUB_REDIRECT_URI. Returns ok=false if any are unset.
+func NewGitHubProvider() (*GitHubProvider, bool) {
+	clientID := os.Getenv("GITHUB_CLIENT_ID")
+	clientSecret := os.Getenv("GITHUB_CLIENT_SECRET")
+	redirectURI := os.Getenv("GITHUB_REDIRECT_URI")
+	if clientID == "" || clientSecret == "" || redirectURI == "" {
+		return nil, false
+	}
+	return &GitHubProvider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURI:  redirectURI,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}, true
+}
+
+func (p *GitHubProvider) Name() string { return "github" }
+
+func (p *GitHubProvider) AuthURL(state, verifier string) string {
+	return "https://github.com/login/oauth/authorize?" + url.Values{
+		"client_id":    {p.ClientID},
+		"redirect_uri": {p.RedirectURI},
+		"scope":        {"read:user user:email"},
+		"state":        {state},
+	}.Encode()
+}
+
+func (p *GitHubProvider) Exchange(ctx context.Context, code, verifier string) (*Token, error) {
+	data := url.Values{}
+	data.Set("client_id", p.ClientID)
+	data.Set("client_secret", p.ClientSecret)
+	data.Set("code", code)
+	data.Set("redirect_uri", p.RedirectURI)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://github.com/login/oauth/access_token", strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GitHub token exchange failed: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return nil, err
+	}
+	if tokenResp.Error != "" {
+		return nil, fmt.Errorf("GitHub token exchange failed: %s", tokenResp.Error)
+	}
+
+	return &Token{AccessToken: tokenResp.AccessToken}, nil
+}
+
+func (p *GitHubProvider) FetchUser(ctx context.Context, token *Token) (*ExternalUser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Authorization", "Bearer "+token.AccessToken)
+	req.Header.Add("Accept", "application/vnd.github+json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GitHub API error: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var userInfo struct {
+		ID        int    `json:"id"`
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&userInfo); err != nil {
+		return nil, err
+	}
+
+	displayName := userInfo.Name
+	if displayName == "" {
+		displayName = userInfo.Login
+	}
+
+	return &ExternalUser{
+		ProviderUserID: strconv.Itoa(userInfo.ID),
+		Username:       userInfo.Login,
+		DisplayName:    displayName,
+		AvatarURL:      userInfo.AvatarURL,
+		// GitHub doesn't expose email verification status on this endpoint; treat
+		// a successful OAuth login as sufficient, same trust level as a GitHub account.
+		Verified: true,
+	}, nil
+}
@@ -0,0 +1,76 @@
+// Package auth abstracts the mechanics of a single OAuth2/OIDC identity
+// provider (Twitter, Google, GitHub, Apple, ...) behind a common interface, so
+// the HTTP layer in internal/api can expose generic /auth/{provider}/start and
+// /auth/{provider}/callback routes instead of one-off handlers per provider.
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Token is the provider-agnostic result of exchanging an authorization code.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    *time.Time
+	Scopes       []string
+}
+
+// ExternalUser is the profile data fetched from a provider after exchange.
+type ExternalUser struct {
+	ProviderUserID string
+	Username       string
+	DisplayName    string
+	AvatarURL      string
+	Verified       bool
+}
+
+// Provider abstracts a single OAuth2/OIDC identity provider.
+type Provider interface {
+	// Name is the registry key and the {provider} path segment, e.g. "twitter".
+	Name() string
+
+	// AuthURL builds the URL to redirect the browser to in order to start
+	// the consent flow. Providers that support PKCE derive their
+	// code_challenge from verifier themselves (S256 unless noted otherwise),
+	// so callers only ever need to hold onto the verifier.
+	AuthURL(state, verifier string) string
+
+	// Exchange trades an authorization code (and, for PKCE providers, the
+	// verifier that produced the original challenge) for an access token.
+	Exchange(ctx context.Context, code, verifier string) (*Token, error)
+
+	// FetchUser retrieves the external profile backing token.
+	FetchUser(ctx context.Context, token *Token) (*ExternalUser, error)
+}
+
+// Refresher is implemented by providers that can mint a new access token from
+// a previously issued refresh token, without sending the user through the
+// consent screen again. Not every Provider supports this (Apple's
+// first-party token refresh, for instance, requires a separately computed
+// client secret), so it's a separate, optional interface rather than part of
+// Provider itself.
+type Refresher interface {
+	Refresh(ctx context.Context, refreshToken string) (*Token, error)
+}
+
+// Registry holds every provider the binary knows how to talk to, keyed by Name().
+type Registry struct {
+	providers map[string]Provider
+}
+
+// NewRegistry builds a registry from the given providers.
+func NewRegistry(providers ...Provider) *Registry {
+	r := &Registry{providers: make(map[string]Provider, len(providers))}
+	for _, p := range providers {
+		r.providers[p.Name()] = p
+	}
+	return r
+}
+
+// Get looks up a provider by name.
+func (r *Registry) Get(name string) (Provider, bool) {
+	p, ok := r.providers[name]
+	return p, ok
+}
@@ -0,0 +1,23 @@
+package auth
+
+// NewRegistryFromEnv builds a Registry containing only the providers whose
+// environment variables are fully configured, so a deployment can enable
+// Twitter/Google/GitHub/Apple independently without code changes.
+func NewRegistryFromEnv() *Registry {
+	var providers []Provider
+
+	if p, ok := NewTwitterProvider(); ok {
+		providers = append(providers, p)
+	}
+	if p, ok := NewGoogleProvider(); ok {
+		providers = append(providers, p)
+	}
+	if p, ok := NewGitHubProvider(); ok {
+		providers = append(providers, p)
+	}
+	if p, ok := NewAppleProvider(); ok {
+		providers = append(providers, p)
+	}
+
+	return NewRegistry(providers...)
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+)
+
+// tokenEncryptionKey returns the AES-256 key (32 raw bytes, base64-std encoded
+// in TOKEN_ENCRYPTION_KEY) used to encrypt provider access/refresh tokens at
+// rest in user_identities. A KMS-backed key management story is out of scope
+// here; this follows the same "secret lives in an env var" convention as
+// JWT_SIGNING_KEYS and OAUTH_COOKIE_SECRET.
+func tokenEncryptionKey() ([]byte, error) {
+	raw := os.Getenv("TOKEN_ENCRYPTION_KEY")
+	if raw == "" {
+		return nil, fmt.Errorf("TOKEN_ENCRYPTION_KEY is not configured")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("TOKEN_ENCRYPTION_KEY is not valid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("TOKEN_ENCRYPTION_KEY must decode to 32 bytes, got %d", len(key))
+	}
+
+	return key, nil
+}
+
+// EncryptToken encrypts plaintext with AES-256-GCM, returning
+// base64(nonce || ciphertext). Returns "" unchanged so callers can encrypt
+// optional fields (e.g. a missing refresh_token) without a branch.
+func EncryptToken(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	key, err := tokenEncryptionKey()
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptToken reverses EncryptToken.
+func DecryptToken(encoded string) (string, error) {
+	if encoded == "" {
+		return "", nil
+	}
+
+	key, err := tokenEncryptionKey()
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted token: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", fmt.Errorf("malformed encrypted token: too short")
+	}
+	nonce, ciphertext := sealed[:nonceSize], sealed[nonceSize:]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt token: %w", err)
+	}
+
+	return string(plaintext), nil
+}
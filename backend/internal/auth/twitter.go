@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// TwitterProvider implements Provider against X (Twitter)'s OAuth2 + v2 API.
+type TwitterProvider struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+	client       *http.Client
+}
+
+// NewTwitterProvider builds a TwitterProvider from TWITTER_CLIENT_ID,
+// TWITTER_CLIENT_SECRET and TWITTER_REDIRECT_URI. Returns ok=false if any are unset.
+func NewTwitterProvider() (*TwitterProvider, bool) {
+	clientID := os.Getenv("TWITTER_CLIENT_ID")
+	clientSecret := os.Getenv("TWITTER_CLIENT_SECRET")
+	redirectURI := os.Getenv("TWITTER_REDIRECT_URI")
+	if clientID == "" || clientSecret == "" || redirectURI == "" {
+		return nil, false
+	}
+	return &TwitterProvider{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURI:  redirectURI,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}, true
+}
+
+func (p *TwitterProvider) Name() string { return "twitter" }
+
+func (p *TwitterProvider) AuthURL(state, verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return "https://x.com/i/oauth2/authorize?" + url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.ClientID},
+		"redirect_uri":          {p.RedirectURI},
+		"scope":                 {"tweet.read users.read offline.access"},
+		"state":                 {state},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+	}.Encode()
+}
+
+func (p *TwitterProvider) Exchange(ctx context.Context, code, verifier string) (*Token, error) {
+	data := url.Values{}
+	data.Set("grant_type", "authorization_code")
+	data.Set("code", code)
+	data.Set("redirect_uri", p.RedirectURI)
+	if verifier != "" {
+		data.Set("code_verifier", verifier)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://x.com/2/oauth2/token", strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(p.ClientID, p.ClientSecret)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		errMsg := fmt.Sprintf("Twitter token exchange failed: %d - %s", resp.StatusCode, string(body))
+		log.Printf("ERROR: %s", errMsg)
+		return nil, fmt.Errorf(errMsg)
+	}
+
+	var tokenResp struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+		Scope        string `json:"scope"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	return &Token{
+		AccessToken:  tokenResp.AccessToken,
+		RefreshToken: tokenResp.RefreshToken,
+		ExpiresAt:    &expiresAt,
+		Scopes:       strings.Fields(tokenResp.Scope),
+	}, nil
+}
+
+// Refresh exchanges a previously issued refresh token for a new access token,
+// per https://developer.x.com/en/docs/authentication/oauth-2-0/user-access-token.
+// Twitter rotates the refresh token on every use, so the returned Token's
+// RefreshToken must replace the one passed in.
+func (p *TwitterProvider) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	data := url.Values{}
+	data.Set("grant_type", "refresh_token")
+	data.Set("refresh_token", refreshToken)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://x.com/2/oauth2/token", strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(p.ClientID, p.ClientSecret)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		errMsg := fmt.Sprintf("Twitter token refresh failed: %d - %s", resp.StatusCode, string(body))
+		log.Printf("ERROR: %s", errMsg)
+		return nil, fmt.Errorf(errMsg)
+	}
+
+	var tokenResp struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+		Scope        string `json:"scope"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	return &Token{
+		AccessToken:  tokenResp.AccessToken,
+		RefreshToken: tokenResp.RefreshToken,
+		ExpiresAt:    &expiresAt,
+		Scopes:       strings.Fields(tokenResp.Scope),
+	}, nil
+}
+
+func (p *TwitterProvider) FetchUser(ctx context.Context, token *Token) (*ExternalUser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.twitter.com/2/users/me?user.fields=verified,profile_image_url", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Authorization", "Bearer "+token.AccessToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Twitter API error: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var twitterResp struct {
+		Data struct {
+			ID              string `json:"id"`
+			Username        string `json:"username"`
+			Name            string `json:"name"`
+			Verified        bool   `json:"verified"`
+			ProfileImageURL string `json:"profile_image_url"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&twitterResp); err != nil {
+		return nil, err
+	}
+
+	return &ExternalUser{
+		ProviderUserID: twitterResp.Data.ID,
+		Username:       twitterResp.Data.Username,
+		DisplayName:    twitterResp.Data.Name,
+		AvatarURL:      twitterResp.Data.ProfileImageURL,
+		Verified:       twitterResp.Data.Verified,
+	}, nil
+}
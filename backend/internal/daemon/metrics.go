@@ -0,0 +1,27 @@
+package daemon
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Prometheus counters exposed by TweetPersister. Registered at package init
+// so they show up on the process's default /metrics endpoint regardless of
+// whether a TweetPersister is actually running.
+var (
+	TweetsIngested = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "timelines_tweets_ingested_total",
+		Help: "Total number of tweets persisted by the TweetPersister daemon.",
+	})
+
+	APIErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "timelines_tweet_persister_api_errors_total",
+		Help: "Total number of errors from the Twitter API encountered by the TweetPersister daemon, by stage.",
+	}, []string{"stage"})
+
+	RateLimitHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "timelines_tweet_persister_rate_limit_hits_total",
+		Help: "Total number of times the TweetPersister daemon was rate limited by the Twitter API.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(TweetsIngested, APIErrors, RateLimitHits)
+}
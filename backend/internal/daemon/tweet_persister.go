@@ -0,0 +1,277 @@
+// Package daemon holds long-running background workers that run alongside
+// the HTTP server (wired into cmd/server as goroutines), as opposed to
+// internal/features, whose plugins only run in response to an HTTP request.
+package daemon
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/timeline/backend/internal/auth"
+	"github.com/timeline/backend/internal/db"
+)
+
+// defaultTweetPersisterInterval is how often TweetPersister polls for new
+// tweets when no interval is configured.
+const defaultTweetPersisterInterval = 60 * time.Second
+
+// TweetPersister periodically fetches new tweets for every user with a
+// linked Twitter identity and caches them in the tweets table, so the rest
+// of the backend can query a user's tweet history without round-tripping to
+// the Twitter API on every request.
+type TweetPersister struct {
+	db       *db.DB
+	registry *auth.Registry
+	interval time.Duration
+	client   *http.Client
+}
+
+// NewTweetPersister builds a TweetPersister. interval <= 0 falls back to
+// defaultTweetPersisterInterval.
+func NewTweetPersister(database *db.DB, registry *auth.Registry, interval time.Duration) *TweetPersister {
+	if interval <= 0 {
+		interval = defaultTweetPersisterInterval
+	}
+	return &TweetPersister{
+		db:       database,
+		registry: registry,
+		interval: interval,
+		client:   &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Run blocks, polling on a ticker until ctx is cancelled. Intended to be
+// started as `go tweetPersister.Run(ctx)` from cmd/server.
+func (p *TweetPersister) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.tick(ctx); err != nil {
+				log.Printf("ERROR: tweet persister tick failed: %v", err)
+			}
+		}
+	}
+}
+
+// twitterIdentity is one row of an active, linked Twitter identity.
+type twitterIdentity struct {
+	userID         string
+	providerUserID string
+	accessToken    string
+	refreshToken   string
+	expiresAt      sql.NullTime
+	lastTweetID    sql.NullString
+}
+
+// tick fetches and persists new tweets for every active Twitter identity.
+func (p *TweetPersister) tick(ctx context.Context) error {
+	identities, err := p.loadActiveIdentities(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load active twitter identities: %w", err)
+	}
+
+	for _, identity := range identities {
+		if err := p.persistForIdentity(ctx, identity); err != nil {
+			APIErrors.WithLabelValues("persist").Inc()
+			log.Printf("ERROR: failed to persist tweets for user %s: %v", identity.userID, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *TweetPersister) loadActiveIdentities(ctx context.Context) ([]twitterIdentity, error) {
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT u.id, i.provider_user_id, i.access_token, i.refresh_token, i.expires_at, i.last_tweet_id
+		 FROM user_identities i
+		 JOIN users u ON u.id = i.user_id
+		 WHERE i.provider = 'twitter' AND u.is_active`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var identities []twitterIdentity
+	for rows.Next() {
+		var identity twitterIdentity
+		if err := rows.Scan(
+			&identity.userID,
+			&identity.providerUserID,
+			&identity.accessToken,
+			&identity.refreshToken,
+			&identity.expiresAt,
+			&identity.lastTweetID,
+		); err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, rows.Err()
+}
+
+// persistForIdentity refreshes identity's access token if needed, fetches any
+// tweets newer than its last_tweet_id, and stores them.
+func (p *TweetPersister) persistForIdentity(ctx context.Context, identity twitterIdentity) error {
+	provider, ok := p.registry.Get("twitter")
+	if !ok {
+		return fmt.Errorf("twitter provider is not configured")
+	}
+
+	accessToken, err := auth.DecryptToken(identity.accessToken)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt access token: %w", err)
+	}
+
+	if identity.expiresAt.Valid && time.Now().After(identity.expiresAt.Time) {
+		refresher, ok := provider.(auth.Refresher)
+		if !ok {
+			return fmt.Errorf("twitter provider does not support token refresh")
+		}
+
+		refreshToken, err := auth.DecryptToken(identity.refreshToken)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt refresh token: %w", err)
+		}
+
+		refreshed, err := refresher.Refresh(ctx, refreshToken)
+		if err != nil {
+			APIErrors.WithLabelValues("refresh").Inc()
+			return fmt.Errorf("failed to refresh token: %w", err)
+		}
+
+		accessToken = refreshed.AccessToken
+		if err := p.storeRefreshedToken(ctx, identity.userID, refreshed); err != nil {
+			return fmt.Errorf("failed to store refreshed token: %w", err)
+		}
+	}
+
+	tweets, err := p.fetchTweets(ctx, accessToken, identity.providerUserID, identity.lastTweetID)
+	if err != nil {
+		APIErrors.WithLabelValues("fetch").Inc()
+		return fmt.Errorf("failed to fetch tweets: %w", err)
+	}
+	if len(tweets) == 0 {
+		return nil
+	}
+
+	return p.storeTweets(ctx, identity.userID, tweets)
+}
+
+func (p *TweetPersister) storeRefreshedToken(ctx context.Context, userID string, token *auth.Token) error {
+	accessToken, err := auth.EncryptToken(token.AccessToken)
+	if err != nil {
+		return err
+	}
+	refreshToken, err := auth.EncryptToken(token.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.db.ExecContext(ctx,
+		`UPDATE user_identities SET access_token = $1, refresh_token = $2, expires_at = $3, scopes = $4
+		 WHERE provider = 'twitter' AND user_id = $5`,
+		accessToken, refreshToken, token.ExpiresAt, pq.Array(token.Scopes), userID,
+	)
+	return err
+}
+
+// fetchedTweet is a single tweet as returned by the Twitter v2 API.
+type fetchedTweet struct {
+	ID        string    `json:"id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// fetchTweets retrieves a single page of at most 100 tweets newer than sinceID
+// (the most recent 100, if sinceID is unset); further pages are not requested,
+// so when more newer tweets exist only the newest 100 are returned. They come
+// back oldest-first so storeTweets can advance last_tweet_id monotonically.
+func (p *TweetPersister) fetchTweets(ctx context.Context, accessToken, twitterUserID string, sinceID sql.NullString) ([]fetchedTweet, error) {
+	url := fmt.Sprintf("https://api.twitter.com/2/users/%s/tweets?tweet.fields=created_at&max_results=100", twitterUserID)
+	if sinceID.Valid {
+		url += "&since_id=" + sinceID.String
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Authorization", "Bearer "+accessToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		RateLimitHits.Inc()
+		return nil, fmt.Errorf("rate limited by Twitter API")
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Twitter API error: %d - %s", resp.StatusCode, string(body))
+	}
+
+	var parsed struct {
+		Data []fetchedTweet `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	// The API returns newest-first; reverse so we insert (and advance
+	// last_tweet_id) oldest-first.
+	for i, j := 0, len(parsed.Data)-1; i < j; i, j = i+1, j-1 {
+		parsed.Data[i], parsed.Data[j] = parsed.Data[j], parsed.Data[i]
+	}
+
+	return parsed.Data, nil
+}
+
+func (p *TweetPersister) storeTweets(ctx context.Context, userID string, tweets []fetchedTweet) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, tweet := range tweets {
+		result, err := tx.ExecContext(ctx,
+			`INSERT INTO tweets (user_id, twitter_tweet_id, text, posted_at)
+			 VALUES ($1, $2, $3, $4)
+			 ON CONFLICT (user_id, twitter_tweet_id) DO NOTHING`,
+			userID, tweet.ID, tweet.Text, tweet.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		if affected, err := result.RowsAffected(); err == nil && affected > 0 {
+			TweetsIngested.Inc()
+		}
+	}
+
+	lastTweetID := tweets[len(tweets)-1].ID
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE user_identities SET last_tweet_id = $1 WHERE provider = 'twitter' AND user_id = $2`,
+		lastTweetID, userID,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/timeline/backend/internal/models"
+)
+
+// zoomTopEventsPerBucket caps how many representative events ListAggregated
+// returns per bucket, regardless of how many events the bucket actually
+// contains (that total is still reported in Bucket.Count).
+const zoomTopEventsPerBucket = 5
+
+// ListAggregated returns a histogram of event counts across bucketCount
+// equal-width buckets spanning [startSec, endSec], plus the top
+// zoomTopEventsPerBucket events by importance_score in each bucket, for
+// rendering a zoomed-out timeline without pulling back every event in a
+// multi-billion-year span. Only events with importance_score >= minImportance
+// are considered.
+func (r *EventRepository) ListAggregated(startSec, endSec int64, bucketCount int, minImportance float64) ([]models.Bucket, []models.Event, error) {
+	if bucketCount <= 0 {
+		return nil, nil, fmt.Errorf("bucketCount must be positive")
+	}
+	if endSec <= startSec {
+		return nil, nil, fmt.Errorf("endSec must be greater than startSec")
+	}
+
+	query := `
+		WITH scored AS (
+			SELECT id, timeline_seconds, unix_seconds, unix_nanos, precision_level, uncertainty_range,
+			       title, description, category, importance_score, related_event_id, relationship_count,
+			       location_count, created_at, updated_at, created_by_user_id, image_url,
+			       LEAST(width_bucket(unix_seconds, $1, $2, $3), $3) AS bucket
+			FROM events
+			WHERE unix_seconds >= $1 AND unix_seconds <= $2 AND importance_score >= $4
+		),
+		ranked AS (
+			SELECT *,
+			       COUNT(*) OVER (PARTITION BY bucket) AS bucket_count,
+			       ROW_NUMBER() OVER (PARTITION BY bucket ORDER BY importance_score DESC, unix_seconds ASC) AS rn
+			FROM scored
+		)
+		SELECT bucket, bucket_count, id, timeline_seconds, unix_seconds, unix_nanos, precision_level, uncertainty_range,
+		       title, description, category, importance_score, related_event_id, relationship_count,
+		       location_count, created_at, updated_at, created_by_user_id, image_url
+		FROM ranked
+		WHERE rn <= $5
+		ORDER BY bucket ASC, rn ASC
+	`
+
+	rows, err := r.db.Query(query, startSec, endSec, bucketCount, minImportance, zoomTopEventsPerBucket)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to aggregate events: %w", err)
+	}
+	defer rows.Close()
+
+	bucketWidth := (endSec - startSec) / int64(bucketCount)
+
+	var buckets []models.Bucket
+	var representatives []models.Event
+	seenBuckets := make(map[int]bool)
+
+	for rows.Next() {
+		var bucketIndex, bucketCountForRow int
+		event := models.Event{}
+		if err := rows.Scan(
+			&bucketIndex, &bucketCountForRow,
+			&event.ID, &event.TimelineSeconds, &event.UnixSeconds, &event.UnixNanos, &event.PrecisionLevel,
+			&event.UncertaintyRange, &event.Title, &event.Description, &event.Category, &event.ImportanceScore,
+			&event.RelatedEventID, &event.RelationshipCount, &event.LocationCount, &event.CreatedAt,
+			&event.UpdatedAt, &event.CreatedByUserID, &event.ImageURL,
+		); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan aggregated event: %w", err)
+		}
+
+		if !seenBuckets[bucketIndex] {
+			seenBuckets[bucketIndex] = true
+			buckets = append(buckets, models.Bucket{
+				Index:        bucketIndex,
+				StartSeconds: startSec + int64(bucketIndex-1)*bucketWidth,
+				EndSeconds:   startSec + int64(bucketIndex)*bucketWidth,
+				Count:        bucketCountForRow,
+			})
+		}
+
+		representatives = append(representatives, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating aggregated events: %w", err)
+	}
+
+	return buckets, representatives, nil
+}
+
+// GetZoomPresetAggregate looks up a zoom preset by name and runs
+// ListAggregated over its [start_seconds, end_seconds] span and
+// min_importance_threshold, so the frontend can ask for "preset + bucket
+// count" and get a ready-to-render histogram without converting Big
+// Bang-relative timeline_seconds to unix_seconds itself.
+func (r *EventRepository) GetZoomPresetAggregate(presetName string, bucketCount int) ([]models.Bucket, []models.Event, error) {
+	presets, err := r.GetZoomPresets()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, preset := range presets {
+		if preset.Name != presetName {
+			continue
+		}
+
+		startSec, err := timelineToUnixSeconds(preset.StartSeconds)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid zoom preset start_seconds: %w", err)
+		}
+		endSec, err := timelineToUnixSeconds(preset.EndSeconds)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid zoom preset end_seconds: %w", err)
+		}
+
+		return r.ListAggregated(startSec, endSec, bucketCount, float64(preset.MinImportanceThreshold))
+	}
+
+	return nil, nil, sql.ErrNoRows
+}
+
+// timelineToUnixSeconds converts a Big Bang-relative timeline_seconds string
+// (as stored on zoom_presets) back to unix_seconds, mirroring the offset
+// EventRepository.createWith applies in the other direction.
+func timelineToUnixSeconds(timelineSeconds string) (int64, error) {
+	value, err := decimal.NewFromString(timelineSeconds)
+	if err != nil {
+		return 0, err
+	}
+	return value.Sub(decimal.NewFromInt(bigBangToEpoch)).IntPart(), nil
+}
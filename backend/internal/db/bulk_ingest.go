@@ -0,0 +1,293 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/timeline/backend/internal/models"
+)
+
+// bulkProgressInterval controls how often BulkCreate/BulkUpsert report
+// progress on the caller's channel, so a multi-thousand-row import doesn't
+// flood it with one message per row.
+const bulkProgressInterval = 500
+
+// IngestProgress is sent on a caller-supplied channel during BulkCreate and
+// BulkUpsert, so a long-running import can drive a progress bar. The
+// channel is closed when the operation returns, whether it succeeds or fails.
+type IngestProgress struct {
+	Processed int
+	Total     int
+}
+
+// BulkItemError reports a single row, by its position in the input slice,
+// that failed validation before the batch was sent to Postgres.
+type BulkItemError struct {
+	Index int
+	Err   error
+}
+
+func (e BulkItemError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Index, e.Err)
+}
+
+// bulkIngestRow is a validated CreateEventRequest paired with the values
+// that need computing once (id, timeline_seconds) before it goes in a
+// pq.CopyIn batch.
+type bulkIngestRow struct {
+	index           int
+	id              string
+	timelineSeconds string
+	req             models.CreateEventRequest
+}
+
+// validateBulkRows checks the one thing COPY can't: whether Title is empty.
+// COPY will still reject a row Postgres itself rejects (e.g. a duplicate
+// external_id in BulkCreate, or a CHECK violation) -- but since COPY is a
+// single wire-protocol operation, a row-level failure there aborts the
+// whole copy. Validating what we can up front keeps most typos in an import
+// from torching a 10,000-row batch over one bad record.
+func validateBulkRows(events []models.CreateEventRequest) ([]bulkIngestRow, []BulkItemError) {
+	rows := make([]bulkIngestRow, 0, len(events))
+	var errs []BulkItemError
+
+	for i, req := range events {
+		if req.Title == "" {
+			errs = append(errs, BulkItemError{Index: i, Err: fmt.Errorf("title is required")})
+			continue
+		}
+
+		rows = append(rows, bulkIngestRow{
+			index:           i,
+			id:              uuid.NewString(),
+			timelineSeconds: timelineSecondsFor(req.UnixSeconds),
+			req:             req,
+		})
+	}
+
+	return rows, errs
+}
+
+func timelineSecondsFor(unixSeconds int64) string {
+	return fmt.Sprintf("%d", unixSeconds+bigBangToEpoch)
+}
+
+func reportBulkProgress(progress chan<- IngestProgress, processed, total int) {
+	if progress == nil {
+		return
+	}
+	if processed < total && processed%bulkProgressInterval != 0 {
+		return
+	}
+	progress <- IngestProgress{Processed: processed, Total: total}
+}
+
+// BulkCreate streams events into Postgres in one round-trip via pq.CopyIn,
+// for importers (Wikidata dumps, historical CSVs) with thousands of rows
+// where issuing one INSERT per row would be too slow. Rows that fail
+// validation are skipped and reported in the returned []BulkItemError
+// instead of aborting the batch; rows Postgres itself rejects abort the
+// whole transaction, since COPY has no per-row error reporting of its own.
+// progress may be nil; if non-nil it is closed before BulkCreate returns.
+func (r *EventRepository) BulkCreate(events []models.CreateEventRequest, userID *string, progress chan<- IngestProgress) ([]models.Event, []BulkItemError, error) {
+	if progress != nil {
+		defer close(progress)
+	}
+
+	rows, rowErrs := validateBulkRows(events)
+	if len(rows) == 0 {
+		return nil, rowErrs, nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, rowErrs, fmt.Errorf("failed to start bulk create transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(pq.CopyIn("events",
+		"id", "timeline_seconds", "unix_seconds", "unix_nanos", "precision_level", "uncertainty_range",
+		"title", "description", "category", "created_by_user_id", "image_url", "external_id",
+	))
+	if err != nil {
+		return nil, rowErrs, fmt.Errorf("failed to prepare bulk copy: %w", err)
+	}
+
+	ids := make([]string, 0, len(rows))
+	for i, row := range rows {
+		if _, err := stmt.Exec(
+			row.id, row.timelineSeconds, row.req.UnixSeconds, row.req.UnixNanos, row.req.PrecisionLevel, row.req.UncertaintyRange,
+			row.req.Title, row.req.Description, row.req.Category, userID, row.req.ImageURL, row.req.ExternalID,
+		); err != nil {
+			stmt.Close()
+			return nil, rowErrs, fmt.Errorf("bulk copy failed at row %d: %w", row.index, err)
+		}
+		ids = append(ids, row.id)
+		reportBulkProgress(progress, i+1, len(rows))
+	}
+
+	if _, err := stmt.Exec(); err != nil {
+		stmt.Close()
+		return nil, rowErrs, fmt.Errorf("bulk copy flush failed: %w", err)
+	}
+	if err := stmt.Close(); err != nil {
+		return nil, rowErrs, fmt.Errorf("bulk copy close failed: %w", err)
+	}
+
+	created, err := r.getByIDsTx(tx, ids)
+	if err != nil {
+		return nil, rowErrs, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, rowErrs, fmt.Errorf("failed to commit bulk create: %w", err)
+	}
+
+	return created, rowErrs, nil
+}
+
+// BulkUpsert behaves like BulkCreate, except rows are matched on
+// external_id: an existing event with the same external_id is updated in
+// place instead of duplicated, so re-running an import (e.g. a refreshed
+// Wikidata export) is idempotent. Every row must have a non-empty
+// ExternalID; rows without one are reported as validation errors.
+func (r *EventRepository) BulkUpsert(events []models.CreateEventRequest, userID *string, progress chan<- IngestProgress) ([]models.Event, []BulkItemError, error) {
+	if progress != nil {
+		defer close(progress)
+	}
+
+	rows, rowErrs := validateBulkRows(events)
+
+	upsertable := rows[:0:0]
+	for _, row := range rows {
+		if row.req.ExternalID == nil || *row.req.ExternalID == "" {
+			rowErrs = append(rowErrs, BulkItemError{Index: row.index, Err: fmt.Errorf("external_id is required for upsert")})
+			continue
+		}
+		upsertable = append(upsertable, row)
+	}
+	if len(upsertable) == 0 {
+		return nil, rowErrs, nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, rowErrs, fmt.Errorf("failed to start bulk upsert transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		CREATE TEMP TABLE bulk_ingest_staging (
+			id UUID, timeline_seconds NUMERIC(38, 0), unix_seconds BIGINT, unix_nanos INTEGER,
+			precision_level TEXT, uncertainty_range BIGINT, title TEXT, description TEXT,
+			category TEXT, created_by_user_id UUID, image_url TEXT, external_id TEXT
+		) ON COMMIT DROP
+	`); err != nil {
+		return nil, rowErrs, fmt.Errorf("failed to create staging table: %w", err)
+	}
+
+	stmt, err := tx.Prepare(pq.CopyIn("bulk_ingest_staging",
+		"id", "timeline_seconds", "unix_seconds", "unix_nanos", "precision_level", "uncertainty_range",
+		"title", "description", "category", "created_by_user_id", "image_url", "external_id",
+	))
+	if err != nil {
+		return nil, rowErrs, fmt.Errorf("failed to prepare staging copy: %w", err)
+	}
+
+	for i, row := range upsertable {
+		if _, err := stmt.Exec(
+			row.id, row.timelineSeconds, row.req.UnixSeconds, row.req.UnixNanos, row.req.PrecisionLevel, row.req.UncertaintyRange,
+			row.req.Title, row.req.Description, row.req.Category, userID, row.req.ImageURL, row.req.ExternalID,
+		); err != nil {
+			stmt.Close()
+			return nil, rowErrs, fmt.Errorf("staging copy failed at row %d: %w", row.index, err)
+		}
+		reportBulkProgress(progress, i+1, len(upsertable))
+	}
+
+	if _, err := stmt.Exec(); err != nil {
+		stmt.Close()
+		return nil, rowErrs, fmt.Errorf("staging copy flush failed: %w", err)
+	}
+	if err := stmt.Close(); err != nil {
+		return nil, rowErrs, fmt.Errorf("staging copy close failed: %w", err)
+	}
+
+	rowsResult, err := tx.Query(`
+		INSERT INTO events (
+			id, timeline_seconds, unix_seconds, unix_nanos, precision_level, uncertainty_range,
+			title, description, category, created_by_user_id, image_url, external_id
+		)
+		SELECT id, timeline_seconds, unix_seconds, unix_nanos, precision_level, uncertainty_range,
+		       title, description, category, created_by_user_id, image_url, external_id
+		FROM bulk_ingest_staging
+		ON CONFLICT (external_id) DO UPDATE SET
+			timeline_seconds = EXCLUDED.timeline_seconds,
+			unix_seconds      = EXCLUDED.unix_seconds,
+			unix_nanos        = EXCLUDED.unix_nanos,
+			precision_level   = EXCLUDED.precision_level,
+			uncertainty_range = EXCLUDED.uncertainty_range,
+			title             = EXCLUDED.title,
+			description       = EXCLUDED.description,
+			category          = EXCLUDED.category,
+			image_url         = EXCLUDED.image_url,
+			updated_at        = NOW()
+		RETURNING id
+	`)
+	if err != nil {
+		return nil, rowErrs, fmt.Errorf("bulk upsert failed: %w", err)
+	}
+
+	var upsertedIDs []string
+	for rowsResult.Next() {
+		var id string
+		if err := rowsResult.Scan(&id); err != nil {
+			rowsResult.Close()
+			return nil, rowErrs, fmt.Errorf("failed to scan upserted id: %w", err)
+		}
+		upsertedIDs = append(upsertedIDs, id)
+	}
+	if err := rowsResult.Err(); err != nil {
+		return nil, rowErrs, fmt.Errorf("error iterating upserted ids: %w", err)
+	}
+	rowsResult.Close()
+
+	upserted, err := r.getByIDsTx(tx, upsertedIDs)
+	if err != nil {
+		return nil, rowErrs, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, rowErrs, fmt.Errorf("failed to commit bulk upsert: %w", err)
+	}
+
+	return upserted, rowErrs, nil
+}
+
+// getByIDsTx fetches events by id within a transaction, in no particular
+// order -- callers that need input order can re-sort by the ids they passed.
+func (r *EventRepository) getByIDsTx(tx *sql.Tx, ids []string) ([]models.Event, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, timeline_seconds, unix_seconds, unix_nanos, precision_level, uncertainty_range,
+		       title, description, category, importance_score, related_event_id, relationship_count,
+		       location_count, created_at, updated_at, created_by_user_id, image_url
+		FROM events
+		WHERE id IN (%s)
+	`, strings.Join(placeholders, ", "))
+
+	return r.scanEvents(tx.Query(query, args...))
+}
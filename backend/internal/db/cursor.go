@@ -0,0 +1,41 @@
+package db
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// eventCursor is the decoded form of an opaque pagination cursor: the triple
+// (unix_seconds, unix_nanos, id) that keyset pagination anchors on.
+type eventCursor struct {
+	UnixSeconds int64  `json:"s"`
+	UnixNanos   int32  `json:"n"`
+	ID          string `json:"id"`
+}
+
+// EncodeCursor base64url-encodes an (unix_seconds, unix_nanos, id) triple into
+// an opaque pagination cursor.
+func EncodeCursor(unixSeconds int64, unixNanos int32, id string) string {
+	raw, _ := json.Marshal(eventCursor{UnixSeconds: unixSeconds, UnixNanos: unixNanos, ID: id})
+	return base64.URLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor reverses EncodeCursor, returning a clear error for malformed input
+// so the handler can surface a 400 instead of a confusing SQL error.
+func DecodeCursor(cursor string) (unixSeconds int64, unixNanos int32, id string, err error) {
+	raw, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+
+	var c eventCursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return 0, 0, "", fmt.Errorf("invalid cursor contents: %w", err)
+	}
+	if c.ID == "" {
+		return 0, 0, "", fmt.Errorf("invalid cursor: missing id")
+	}
+
+	return c.UnixSeconds, c.UnixNanos, c.ID, nil
+}
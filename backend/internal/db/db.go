@@ -12,6 +12,15 @@ import (
 // DB wraps the database connection
 type DB struct {
 	*sql.DB
+
+	// stmts caches prepared statements for callers (EventRepository.List,
+	// Update) whose SQL text varies with which filter/update fields are
+	// present -- see statement_cache.go.
+	stmts *stmtCache
+
+	// explainThreshold is the EXPLAIN ANALYZE total cost above which
+	// WithExplain logs the plan. 0 (the default) disables explain-logging.
+	explainThreshold float64
 }
 
 // Config holds database configuration
@@ -67,7 +76,7 @@ func NewFromURL(databaseURL string) (*DB, error) {
 		log.Printf("Tables visible in public schema: %v", tables)
 	}
 
-	return &DB{db}, nil
+	return &DB{DB: db, stmts: newStmtCache()}, nil
 }
 
 // New creates a new database connection
@@ -145,7 +154,7 @@ func New(config Config) (*DB, error) {
 		log.Printf("Current search_path: %s", searchPath)
 	}
 
-	return &DB{db}, nil
+	return &DB{DB: db, stmts: newStmtCache()}, nil
 }
 
 // Close closes the database connection
@@ -157,3 +166,9 @@ func (db *DB) Close() error {
 func (db *DB) Health() error {
 	return db.Ping()
 }
+
+// SetExplainThreshold sets the EXPLAIN ANALYZE total cost above which
+// WithExplain logs a query's plan. Pass 0 to disable explain-logging.
+func (db *DB) SetExplainThreshold(cost float64) {
+	db.explainThreshold = cost
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// NewStoreFromURL opens databaseURL and returns the Store implementation
+// matching its scheme: "postgres://" / "postgresql://" for the existing
+// Postgres-backed EventRepository, "sqlite://" for SQLiteEventRepository.
+// The scheme-based dispatch is what lets a deployment switch backends
+// (a Postgres service in production, a local SQLite file for development,
+// CI, or a single-binary offline distribution) without any code change.
+func NewStoreFromURL(databaseURL string) (Store, *DB, error) {
+	parsed, err := url.Parse(databaseURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid DATABASE_URL: %w", err)
+	}
+
+	switch parsed.Scheme {
+	case "postgres", "postgresql":
+		database, err := NewFromURL(databaseURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		return NewEventRepository(database), database, nil
+
+	case "sqlite", "sqlite3":
+		database, err := NewSQLiteFromURL(databaseURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		return NewSQLiteEventRepository(database), database, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unsupported DATABASE_URL scheme %q (expected postgres:// or sqlite://)", parsed.Scheme)
+	}
+}
@@ -0,0 +1,204 @@
+package db
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/timeline/backend/internal/models"
+	"github.com/timeline/backend/internal/utils"
+)
+
+// fieldDiff is one changed field in an event_history.diff JSONB document.
+type fieldDiff struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// buildEventDiff compares the fields a user can change via
+// UpdateEventRequest and returns only the ones that actually changed.
+// Computed fields (importance_score, relationship_count, ...) aren't user
+// edits, so they're left out of the audit trail.
+func buildEventDiff(before, after *models.Event) map[string]fieldDiff {
+	diff := map[string]fieldDiff{}
+
+	add := func(field string, oldVal, newVal interface{}) {
+		oldJSON, _ := json.Marshal(oldVal)
+		newJSON, _ := json.Marshal(newVal)
+		if !bytes.Equal(oldJSON, newJSON) {
+			diff[field] = fieldDiff{Old: oldVal, New: newVal}
+		}
+	}
+
+	add("title", before.Title, after.Title)
+	add("description", before.Description, after.Description)
+	add("category", before.Category, after.Category)
+	add("image_url", before.ImageURL, after.ImageURL)
+	add("unix_seconds", before.UnixSeconds, after.UnixSeconds)
+	add("unix_nanos", before.UnixNanos, after.UnixNanos)
+	add("precision_level", before.PrecisionLevel, after.PrecisionLevel)
+	add("uncertainty_range", before.UncertaintyRange, after.UncertaintyRange)
+
+	return diff
+}
+
+// recordHistory writes an event_history row for the fields that changed
+// between before and after, inside the caller's transaction. A no-op update
+// (nothing changed) writes nothing.
+func (r *EventRepository) recordHistory(tx *sql.Tx, before, after *models.Event, changedByUserID *string, reason string) error {
+	diff := buildEventDiff(before, after)
+	if len(diff) == 0 {
+		return nil
+	}
+
+	diffJSON, err := json.Marshal(diff)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event diff: %w", err)
+	}
+
+	var reasonPtr *string
+	if reason != "" {
+		reasonPtr = &reason
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO event_history (event_id, revision, changed_by_user_id, diff, reason)
+		VALUES ($1, (SELECT COALESCE(MAX(revision), 0) + 1 FROM event_history WHERE event_id = $1), $2, $3::jsonb, $4)
+	`, after.ID, changedByUserID, diffJSON, reasonPtr)
+	if err != nil {
+		return fmt.Errorf("failed to record event history: %w", err)
+	}
+
+	return nil
+}
+
+// GetHistory returns eventID's revisions, most recent first.
+func (r *EventRepository) GetHistory(eventID string) ([]models.EventRevision, error) {
+	rows, err := r.db.Query(`
+		SELECT id, event_id, revision, changed_at, changed_by_user_id, diff::text, reason
+		FROM event_history
+		WHERE event_id = $1
+		ORDER BY revision DESC
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event history: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []models.EventRevision
+	for rows.Next() {
+		rev := models.EventRevision{}
+		if err := rows.Scan(&rev.ID, &rev.EventID, &rev.Revision, &rev.ChangedAt, &rev.ChangedByUserID, &rev.Diff, &rev.Reason); err != nil {
+			return nil, fmt.Errorf("failed to scan event revision: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, rows.Err()
+}
+
+// Revert restores eventID to the state it was in as of revision, by
+// replaying the "old" side of every field changed in revisions after it,
+// earliest first so the oldest recorded value for each field wins. Fields
+// never touched after revision are left as they are now. Note this can't
+// revert a field back to NULL (UpdateEventRequest has no way to express
+// "clear this field" versus "don't touch this field"), so restoring a
+// revision that had e.g. a blank description just leaves the current one.
+func (r *EventRepository) Revert(eventID string, revision int) (*models.Event, error) {
+	rows, err := r.db.Query(`
+		SELECT diff::text FROM event_history
+		WHERE event_id = $1 AND revision > $2
+		ORDER BY revision ASC
+	`, eventID, revision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load revisions to revert: %w", err)
+	}
+
+	restore := map[string]json.RawMessage{}
+	for rows.Next() {
+		var diffText string
+		if err := rows.Scan(&diffText); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan revision diff: %w", err)
+		}
+
+		var diff map[string]fieldDiff
+		if err := json.Unmarshal([]byte(diffText), &diff); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to parse revision diff: %w", err)
+		}
+		for field, fd := range diff {
+			if _, seen := restore[field]; !seen {
+				oldJSON, err := json.Marshal(fd.Old)
+				if err != nil {
+					rows.Close()
+					return nil, fmt.Errorf("failed to re-marshal old value for %s: %w", field, err)
+				}
+				restore[field] = oldJSON
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating revisions to revert: %w", err)
+	}
+	rows.Close()
+
+	if len(restore) == 0 {
+		return r.GetByID(eventID)
+	}
+
+	req := models.UpdateEventRequest{}
+	for field, raw := range restore {
+		switch field {
+		case "title":
+			var v string
+			if json.Unmarshal(raw, &v) == nil {
+				req.Title = &v
+			}
+		case "description":
+			var v *string
+			if json.Unmarshal(raw, &v) == nil && v != nil {
+				req.Description = v
+			}
+		case "category":
+			var v *string
+			if json.Unmarshal(raw, &v) == nil && v != nil {
+				req.Category = v
+			}
+		case "image_url":
+			var v *string
+			if json.Unmarshal(raw, &v) == nil && v != nil {
+				req.ImageURL = v
+			}
+		case "unix_seconds":
+			var v int64
+			if json.Unmarshal(raw, &v) == nil {
+				req.UnixSeconds = &v
+			}
+		case "unix_nanos":
+			var v int32
+			if json.Unmarshal(raw, &v) == nil {
+				req.UnixNanos = &v
+			}
+		case "precision_level":
+			var v utils.PrecisionLevel
+			if json.Unmarshal(raw, &v) == nil {
+				req.PrecisionLevel = &v
+			}
+		case "uncertainty_range":
+			// Diffed from models.Event.UncertaintyRange, which is a *string
+			// (the driver returns it as text), not the *int64
+			// UpdateEventRequest expects - decode as the stored type, then parse.
+			var v *string
+			if json.Unmarshal(raw, &v) == nil && v != nil {
+				if n, err := strconv.ParseInt(*v, 10, 64); err == nil {
+					req.UncertaintyRange = &n
+				}
+			}
+		}
+	}
+
+	return r.updateWithReason(eventID, req, nil, fmt.Sprintf("revert to revision %d", revision))
+}
@@ -19,8 +19,26 @@ func NewEventRepository(db *DB) *EventRepository {
 	return &EventRepository{db: db}
 }
 
+// execer is satisfied by both *DB and *sql.Tx, letting the Create/Update/Delete
+// query bodies run either against the pool directly or inside a caller-managed
+// transaction (e.g. the batch endpoint's per-item savepoints).
+type execer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
 // Create inserts a new event
 func (r *EventRepository) Create(req models.CreateEventRequest, userID *string) (*models.Event, error) {
+	return r.createWith(r.db, req, userID)
+}
+
+// CreateTx inserts a new event using the given transaction, so callers can
+// batch several creates (and updates/deletes) into one atomic unit of work.
+func (r *EventRepository) CreateTx(tx *sql.Tx, req models.CreateEventRequest, userID *string) (*models.Event, error) {
+	return r.createWith(tx, req, userID)
+}
+
+func (r *EventRepository) createWith(exec execer, req models.CreateEventRequest, userID *string) (*models.Event, error) {
 	// Convert unix_seconds to timeline_seconds
 	// Formula: timeline_seconds = unix_seconds + 435494878264400000 (Big Bang offset)
 	const BIG_BANG_TO_EPOCH int64 = 435494878264400000
@@ -38,7 +56,7 @@ func (r *EventRepository) Create(req models.CreateEventRequest, userID *string)
 	`
 
 	event := &models.Event{}
-	err := r.db.QueryRow(
+	err := exec.QueryRow(
 		query,
 		timelineSeconds,
 		req.UnixSeconds,
@@ -79,6 +97,10 @@ func (r *EventRepository) Create(req models.CreateEventRequest, userID *string)
 
 // GetByID retrieves an event by ID
 func (r *EventRepository) GetByID(id string) (*models.Event, error) {
+	return r.getByIDWith(r.db, id)
+}
+
+func (r *EventRepository) getByIDWith(exec execer, id string) (*models.Event, error) {
 	query := `
 		SELECT id, timeline_seconds, unix_seconds, unix_nanos, precision_level, uncertainty_range,
 		       title, description, category, importance_score, related_event_id, relationship_count,
@@ -88,7 +110,7 @@ func (r *EventRepository) GetByID(id string) (*models.Event, error) {
 	`
 
 	event := &models.Event{}
-	err := r.db.QueryRow(query, id).Scan(
+	err := exec.QueryRow(query, id).Scan(
 		&event.ID,
 		&event.TimelineSeconds,
 		&event.UnixSeconds,
@@ -146,7 +168,13 @@ func (r *EventRepository) List(params models.EventQueryParams) ([]models.Event,
 	}
 
 	if params.Category != nil {
-		query += fmt.Sprintf(" AND category = $%d", argCount)
+		if params.IncludeDescendants {
+			query += fmt.Sprintf(` AND category IN (
+				SELECT descendant FROM category_closure WHERE ancestor = $%d
+			)`, argCount)
+		} else {
+			query += fmt.Sprintf(" AND category = $%d", argCount)
+		}
 		args = append(args, *params.Category)
 		argCount++
 	}
@@ -163,10 +191,43 @@ func (r *EventRepository) List(params models.EventQueryParams) ([]models.Event,
 		argCount++
 	}
 
-	// Order by unix_seconds for proper chronological ordering
-	query += " ORDER BY unix_seconds ASC"
+	// Keyset pagination: after/before encode (unix_seconds, unix_nanos, id) and
+	// are translated into a row-value predicate against the same triple we
+	// order by, which stays efficient at any page depth unlike OFFSET.
+	if params.After != nil && *params.After != "" {
+		afterSeconds, afterNanos, afterID, err := DecodeCursor(*params.After)
+		if err != nil {
+			return nil, fmt.Errorf("invalid after cursor: %w", err)
+		}
+		query += fmt.Sprintf(" AND (unix_seconds, unix_nanos, id) > ($%d, $%d, $%d)", argCount, argCount+1, argCount+2)
+		args = append(args, afterSeconds, afterNanos, afterID)
+		argCount += 3
+	}
+
+	if params.Before != nil && *params.Before != "" {
+		beforeSeconds, beforeNanos, beforeID, err := DecodeCursor(*params.Before)
+		if err != nil {
+			return nil, fmt.Errorf("invalid before cursor: %w", err)
+		}
+		query += fmt.Sprintf(" AND (unix_seconds, unix_nanos, id) < ($%d, $%d, $%d)", argCount, argCount+1, argCount+2)
+		args = append(args, beforeSeconds, beforeNanos, beforeID)
+		argCount += 3
+	}
+
+	// Order by the same triple the cursor encodes, for a stable total order.
+	// Paging backward ("before") needs the rows immediately preceding the
+	// cursor, not the earliest rows in the whole dataset, so it must walk the
+	// triple DESC under the predicate and take the nearest N; we reverse the
+	// scanned rows below to restore ascending order in the response.
+	pagingBackward := params.Before != nil && *params.Before != "" && (params.After == nil || *params.After == "")
+	if pagingBackward {
+		query += " ORDER BY unix_seconds DESC, unix_nanos DESC, id DESC"
+	} else {
+		query += " ORDER BY unix_seconds ASC, unix_nanos ASC, id ASC"
+	}
 
 	// Add pagination
+	hasOffset := params.After == nil && params.Before == nil && params.Offset > 0
 	if params.Limit > 0 {
 		query += fmt.Sprintf(" LIMIT $%d", argCount)
 		args = append(args, params.Limit)
@@ -177,14 +238,18 @@ func (r *EventRepository) List(params models.EventQueryParams) ([]models.Event,
 		argCount++
 	}
 
-	if params.Offset > 0 {
+	if hasOffset {
 		query += fmt.Sprintf(" OFFSET $%d", argCount)
 		args = append(args, params.Offset)
 		argCount++
 	}
 
-	// Execute query
-	rows, err := r.db.Query(query, args...)
+	// Execute query as a cached prepared statement: the query text above is
+	// deterministic for a given combination of which filters are set, so
+	// listQuerySignature lets repeated calls with the same shape reuse one
+	// plan instead of each becoming its own one-off unprepared query.
+	signature := listQuerySignature(params, hasOffset, pagingBackward)
+	rows, err := r.db.PreparedQuery(signature, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list events: %w", err)
 	}
@@ -218,11 +283,241 @@ func (r *EventRepository) List(params models.EventQueryParams) ([]models.Event,
 		events = append(events, event)
 	}
 
+	if pagingBackward {
+		for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+			events[i], events[j] = events[j], events[i]
+		}
+	}
+
+	return events, nil
+}
+
+// listQuerySignature encodes which optional filters are present in a List
+// call (and whether it's a descendant-inclusive category match, or an
+// OFFSET-paginated page) into a short string. The query text List builds is
+// fully determined by that combination, so it doubles as the cache key
+// statement_cache.go needs to reuse a prepared statement across calls with
+// the same shape but different argument values.
+func listQuerySignature(params models.EventQueryParams, hasOffset bool, pagingBackward bool) string {
+	var sig strings.Builder
+	sig.WriteString("list")
+	if params.StartSeconds != nil {
+		sig.WriteString(":start")
+	}
+	if params.EndSeconds != nil {
+		sig.WriteString(":end")
+	}
+	if params.Category != nil {
+		if params.IncludeDescendants {
+			sig.WriteString(":category_desc")
+		} else {
+			sig.WriteString(":category")
+		}
+	}
+	if params.MinImportance != nil {
+		sig.WriteString(":min_importance")
+	}
+	if params.Search != nil && *params.Search != "" {
+		sig.WriteString(":search")
+	}
+	if params.After != nil && *params.After != "" {
+		sig.WriteString(":after")
+	}
+	if params.Before != nil && *params.Before != "" {
+		sig.WriteString(":before")
+	}
+	if hasOffset {
+		sig.WriteString(":offset")
+	}
+	if pagingBackward {
+		sig.WriteString(":backward")
+	}
+	return sig.String()
+}
+
+// ListForUser retrieves events created by userID ("mine" selector)
+func (r *EventRepository) ListForUser(userID string, params models.EventQueryParams) ([]models.Event, error) {
+	query := `
+		SELECT id, timeline_seconds, unix_seconds, unix_nanos, precision_level, uncertainty_range,
+		       title, description, category, importance_score, related_event_id, relationship_count,
+		       location_count, created_at, updated_at, created_by_user_id, image_url
+		FROM events
+		WHERE created_by_user_id = $1
+		ORDER BY unix_seconds ASC, unix_nanos ASC, id ASC
+		LIMIT $2
+	`
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	return r.scanEvents(r.db.Query(query, userID, limit))
+}
+
+// ListVotedByUser retrieves events userID has cast any vote on ("voted" selector)
+func (r *EventRepository) ListVotedByUser(userID string, params models.EventQueryParams) ([]models.Event, error) {
+	query := `
+		SELECT e.id, e.timeline_seconds, e.unix_seconds, e.unix_nanos, e.precision_level, e.uncertainty_range,
+		       e.title, e.description, e.category, e.importance_score, e.related_event_id, e.relationship_count,
+		       e.location_count, e.created_at, e.updated_at, e.created_by_user_id, e.image_url
+		FROM events e
+		JOIN votes v ON v.event_id = e.id
+		WHERE v.user_id = $1
+		ORDER BY e.unix_seconds ASC, e.unix_nanos ASC, e.id ASC
+		LIMIT $2
+	`
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	return r.scanEvents(r.db.Query(query, userID, limit))
+}
+
+// ListSourcedByUser retrieves events userID has added a source to ("sourced_by_me" selector)
+func (r *EventRepository) ListSourcedByUser(userID string, params models.EventQueryParams) ([]models.Event, error) {
+	query := `
+		SELECT DISTINCT e.id, e.timeline_seconds, e.unix_seconds, e.unix_nanos, e.precision_level, e.uncertainty_range,
+		       e.title, e.description, e.category, e.importance_score, e.related_event_id, e.relationship_count,
+		       e.location_count, e.created_at, e.updated_at, e.created_by_user_id, e.image_url
+		FROM events e
+		JOIN event_sources es ON es.event_id = e.id
+		WHERE es.added_by_user_id = $1
+		ORDER BY e.unix_seconds ASC, e.unix_nanos ASC, e.id ASC
+		LIMIT $2
+	`
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	return r.scanEvents(r.db.Query(query, userID, limit))
+}
+
+// ListRelatedTo retrieves events reachable via event_relationships from eventID
+// in either direction, ordered by relationship weight ("related_to" selector)
+func (r *EventRepository) ListRelatedTo(eventID string, params models.EventQueryParams) ([]models.Event, error) {
+	query := `
+		SELECT e.id, e.timeline_seconds, e.unix_seconds, e.unix_nanos, e.precision_level, e.uncertainty_range,
+		       e.title, e.description, e.category, e.importance_score, e.related_event_id, e.relationship_count,
+		       e.location_count, e.created_at, e.updated_at, e.created_by_user_id, e.image_url
+		FROM event_relationships r
+		JOIN events e ON e.id = CASE WHEN r.event_id_a = $1 THEN r.event_id_b ELSE r.event_id_a END
+		WHERE r.event_id_a = $1 OR r.event_id_b = $1
+		ORDER BY r.weight DESC
+		LIMIT $2
+	`
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	return r.scanEvents(r.db.Query(query, eventID, limit))
+}
+
+// scanEvents drains a *sql.Rows of the standard events column set into a slice.
+func (r *EventRepository) scanEvents(rows *sql.Rows, err error) ([]models.Event, error) {
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.Event{}
+	for rows.Next() {
+		event := models.Event{}
+		err := rows.Scan(
+			&event.ID,
+			&event.TimelineSeconds,
+			&event.UnixSeconds,
+			&event.UnixNanos,
+			&event.PrecisionLevel,
+			&event.UncertaintyRange,
+			&event.Title,
+			&event.Description,
+			&event.Category,
+			&event.ImportanceScore,
+			&event.RelatedEventID,
+			&event.RelationshipCount,
+			&event.LocationCount,
+			&event.CreatedAt,
+			&event.UpdatedAt,
+			&event.CreatedByUserID,
+			&event.ImageURL,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+
 	return events, nil
 }
 
 // Update updates an event
 func (r *EventRepository) Update(id string, req models.UpdateEventRequest) (*models.Event, error) {
+	return r.updateWithReason(id, req, nil, "")
+}
+
+// updateWithReason updates an event and records the change in event_history
+// in the same transaction, attributing it to changedByUserID with the given
+// reason (both optional -- pass nil/"" when the caller doesn't have either).
+func (r *EventRepository) updateWithReason(id string, req models.UpdateEventRequest, changedByUserID *string, reason string) (*models.Event, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start update transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := r.getByIDWith(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := r.updateWith(tx, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.recordHistory(tx, before, after, changedByUserID, reason); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit update: %w", err)
+	}
+
+	return after, nil
+}
+
+// UpdateTx updates an event using the given transaction, also recording the
+// change in event_history within that same transaction.
+func (r *EventRepository) UpdateTx(tx *sql.Tx, id string, req models.UpdateEventRequest) (*models.Event, error) {
+	before, err := r.getByIDWith(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := r.updateWith(tx, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.recordHistory(tx, before, after, nil, ""); err != nil {
+		return nil, err
+	}
+
+	return after, nil
+}
+
+// updateWith always runs inside a caller-managed transaction (updateWithReason
+// and UpdateTx each begin/receive one before calling in), so it can go
+// through the prepared-statement cache via PreparedStmtTx rather than the
+// execer interface the Create/Delete paths use.
+func (r *EventRepository) updateWith(tx *sql.Tx, id string, req models.UpdateEventRequest) (*models.Event, error) {
 	// Build update query dynamically
 	updates := []string{}
 	args := []interface{}{}
@@ -280,6 +575,12 @@ func (r *EventRepository) Update(id string, req models.UpdateEventRequest) (*mod
 		return r.GetByID(id)
 	}
 
+	// updateQuerySignature is derived from the assigned columns only (not
+	// their $N placeholders, which shift with argCount), so the same set of
+	// fields being updated always maps to the same cache key regardless of
+	// the values involved.
+	signature := updateQuerySignature(updates)
+
 	updates = append(updates, "updated_at = NOW()")
 	args = append(args, id)
 
@@ -292,8 +593,13 @@ func (r *EventRepository) Update(id string, req models.UpdateEventRequest) (*mod
 		          location_count, created_at, updated_at, created_by_user_id, image_url
 	`, strings.Join(updates, ", "), argCount)
 
+	stmt, err := r.db.PreparedStmtTx(tx, signature, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare update: %w", err)
+	}
+
 	event := &models.Event{}
-	err := r.db.QueryRow(query, args...).Scan(
+	err = stmt.QueryRow(args...).Scan(
 		&event.ID,
 		&event.TimelineSeconds,
 		&event.UnixSeconds,
@@ -323,11 +629,30 @@ func (r *EventRepository) Update(id string, req models.UpdateEventRequest) (*mod
 	return event, nil
 }
 
+// updateQuerySignature turns the list of "column = $N" assignments updateWith
+// built into a cache key naming just the columns, e.g. "update:title:category".
+func updateQuerySignature(updates []string) string {
+	columns := make([]string, len(updates))
+	for i, u := range updates {
+		columns[i] = strings.TrimSpace(strings.SplitN(u, "=", 2)[0])
+	}
+	return "update:" + strings.Join(columns, ":")
+}
+
 // Delete deletes an event
 func (r *EventRepository) Delete(id string) error {
+	return r.deleteWith(r.db, id)
+}
+
+// DeleteTx deletes an event using the given transaction.
+func (r *EventRepository) DeleteTx(tx *sql.Tx, id string) error {
+	return r.deleteWith(tx, id)
+}
+
+func (r *EventRepository) deleteWith(exec execer, id string) error {
 	query := `DELETE FROM events WHERE id = $1`
 
-	result, err := r.db.Exec(query, id)
+	result, err := exec.Exec(query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete event: %w", err)
 	}
@@ -458,7 +783,7 @@ func (r *EventRepository) GetSourcesByEventIDs(eventIDs []string) (map[string][]
 		SELECT id, event_id, source_type, title, url, citation, credibility_score, added_by_user_id, created_at
 		FROM event_sources
 		WHERE event_id IN (%s)
-		ORDER BY event_id, created_at DESC
+		ORDER BY event_id, created_at DESC, id
 	`, strings.Join(placeholders, ", "))
 
 	rows, err := r.db.Query(query, args...)
@@ -495,7 +820,11 @@ func (r *EventRepository) GetSourcesByEventIDs(eventIDs []string) (map[string][]
 	return sourcesMap, nil
 }
 
-// GetRelationshipsByEventIDs retrieves relationships for multiple events in a single query (batch optimization)
+// GetRelationshipsByEventIDs retrieves relationships for multiple events in a
+// single query (batch optimization). Ordered by weight DESC, id ASC so ties on
+// weight (common; weight defaults to the same value for most relationships)
+// still come back in a stable order instead of depending on the database's
+// incidental scan order.
 func (r *EventRepository) GetRelationshipsByEventIDs(eventIDs []string) (map[string][]*models.EventRelationship, error) {
 	if len(eventIDs) == 0 {
 		return make(map[string][]*models.EventRelationship), nil
@@ -513,7 +842,7 @@ func (r *EventRepository) GetRelationshipsByEventIDs(eventIDs []string) (map[str
 		SELECT id, event_id_a, event_id_b, relationship_type, weight, relationship_description
 		FROM event_relationships
 		WHERE event_id_a IN (%s) OR event_id_b IN (%s)
-		ORDER BY weight DESC
+		ORDER BY weight DESC, id ASC
 	`, strings.Join(placeholders, ", "), strings.Join(placeholders, ", "))
 
 	// Duplicate args for the second IN clause
@@ -552,3 +881,143 @@ func (r *EventRepository) GetRelationshipsByEventIDs(eventIDs []string) (map[str
 
 	return relationshipsMap, nil
 }
+
+// maxRelationshipTraversalDepth caps GetRelatedEventsRecursive regardless of
+// what the caller requests, so a large maxDepth can't turn the recursive CTE
+// into a full-table walk.
+const maxRelationshipTraversalDepth = 10
+
+// CreateRelationship links two events with a typed, weighted relationship.
+// event_relationships.relationship_type is also CHECK-constrained at the
+// database level; validating here just gets the caller a clearer error
+// instead of a raw constraint-violation message.
+func (r *EventRepository) CreateRelationship(a, b string, relType string, weight decimal.Decimal, desc string) (*models.EventRelationship, error) {
+	if !models.RelationshipType(relType).IsValid() {
+		return nil, fmt.Errorf("invalid relationship type: %s", relType)
+	}
+
+	var description *string
+	if desc != "" {
+		description = &desc
+	}
+
+	rel := &models.EventRelationship{}
+	err := r.db.QueryRow(
+		`INSERT INTO event_relationships (event_id_a, event_id_b, relationship_type, weight, relationship_description)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING id, event_id_a, event_id_b, relationship_type, weight, relationship_description, created_at`,
+		a, b, relType, weight, description,
+	).Scan(
+		&rel.ID,
+		&rel.EventIDA,
+		&rel.EventIDB,
+		&rel.RelationshipType,
+		&rel.Weight,
+		&rel.Description,
+		&rel.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create relationship: %w", err)
+	}
+
+	// events.relationship_count is kept in sync by
+	// trg_maintain_event_relationship_count (see migrations/0006).
+	return rel, nil
+}
+
+// DeleteRelationship removes a relationship by ID. events.relationship_count
+// is kept in sync by trg_maintain_event_relationship_count.
+func (r *EventRepository) DeleteRelationship(id string) error {
+	result, err := r.db.Exec(`DELETE FROM event_relationships WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete relationship: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to confirm relationship deletion: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetRelationshipsByType retrieves, paginated, every relationship of relType
+// touching eventID in either direction, most heavily-weighted first.
+func (r *EventRepository) GetRelationshipsByType(eventID string, relType string, limit, offset int) ([]*models.EventRelationship, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := r.db.Query(
+		`SELECT id, event_id_a, event_id_b, relationship_type, weight, relationship_description, created_at
+		 FROM event_relationships
+		 WHERE (event_id_a = $1 OR event_id_b = $1) AND relationship_type = $2
+		 ORDER BY weight DESC, id ASC
+		 LIMIT $3 OFFSET $4`,
+		eventID, relType, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relationships by type: %w", err)
+	}
+	defer rows.Close()
+
+	var relationships []*models.EventRelationship
+	for rows.Next() {
+		rel := &models.EventRelationship{}
+		if err := rows.Scan(
+			&rel.ID,
+			&rel.EventIDA,
+			&rel.EventIDB,
+			&rel.RelationshipType,
+			&rel.Weight,
+			&rel.Description,
+			&rel.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan event relationship: %w", err)
+		}
+		relationships = append(relationships, rel)
+	}
+
+	return relationships, rows.Err()
+}
+
+// GetRelatedEventsRecursive walks event_relationships outward from eventID via
+// a recursive CTE, following only edges of relType, up to maxDepth hops
+// (capped at maxRelationshipTraversalDepth), and returns the events reached
+// -- not including eventID itself.
+func (r *EventRepository) GetRelatedEventsRecursive(eventID string, relType string, maxDepth int) ([]models.Event, error) {
+	if maxDepth <= 0 || maxDepth > maxRelationshipTraversalDepth {
+		maxDepth = maxRelationshipTraversalDepth
+	}
+
+	query := `
+		WITH RECURSIVE traversal(event_id, depth, path) AS (
+			SELECT $1::UUID, 0, ARRAY[$1::UUID]
+
+			UNION ALL
+
+			SELECT
+				CASE WHEN r.event_id_a = t.event_id THEN r.event_id_b ELSE r.event_id_a END,
+				t.depth + 1,
+				t.path || (CASE WHEN r.event_id_a = t.event_id THEN r.event_id_b ELSE r.event_id_a END)
+			FROM event_relationships r
+			JOIN traversal t ON r.event_id_a = t.event_id OR r.event_id_b = t.event_id
+			WHERE r.relationship_type = $2
+			  AND t.depth < $3
+			  AND NOT (CASE WHEN r.event_id_a = t.event_id THEN r.event_id_b ELSE r.event_id_a END) = ANY(t.path)
+		)
+		SELECT DISTINCT e.id, e.timeline_seconds, e.unix_seconds, e.unix_nanos, e.precision_level,
+		       e.uncertainty_range, e.title, e.description, e.category, e.importance_score,
+		       e.related_event_id, e.relationship_count, e.location_count, e.created_at,
+		       e.updated_at, e.created_by_user_id, e.image_url
+		FROM traversal t
+		JOIN events e ON e.id = t.event_id
+		WHERE t.depth > 0
+		ORDER BY e.unix_seconds ASC, e.unix_nanos ASC, e.id ASC
+	`
+
+	return r.scanEvents(r.db.Query(query, eventID, relType, maxDepth))
+}
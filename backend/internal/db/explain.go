@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+)
+
+// WithExplain runs query through EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON)
+// before executing it for real, and logs the plan when its total cost meets
+// or exceeds the threshold set by SetExplainThreshold (0, the default,
+// disables this entirely). Meant for an operator chasing down a specific
+// slow query, not routine use: EXPLAIN ANALYZE actually runs the query, so
+// WithExplain executes it twice.
+func (db *DB) WithExplain(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if db.explainThreshold > 0 {
+		if cost, planJSON, ok := db.explainCost(ctx, query, args...); ok && cost >= db.explainThreshold {
+			log.Printf("slow query plan (cost=%.1f >= threshold=%.1f): %s\n%s", cost, db.explainThreshold, query, planJSON)
+		}
+	}
+
+	return db.QueryContext(ctx, query, args...)
+}
+
+// explainCost runs EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) for query and
+// extracts the top-level plan's total cost. The bool return is false if the
+// explain itself failed or its output couldn't be parsed -- either way,
+// WithExplain just runs the real query without logging.
+func (db *DB) explainCost(ctx context.Context, query string, args ...interface{}) (float64, string, bool) {
+	var planJSON string
+	err := db.QueryRowContext(ctx, "EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) "+query, args...).Scan(&planJSON)
+	if err != nil {
+		log.Printf("explain failed for query: %v", err)
+		return 0, "", false
+	}
+
+	var plans []struct {
+		Plan struct {
+			TotalCost float64 `json:"Total Cost"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(planJSON), &plans); err != nil || len(plans) == 0 {
+		return 0, "", false
+	}
+
+	return plans[0].Plan.TotalCost, planJSON, true
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/url"
+
+	_ "modernc.org/sqlite" // pure-Go driver, registers as "sqlite" -- no cgo, so a single static binary still works
+)
+
+// NewSQLiteFromURL opens a SQLite-backed DB from a "sqlite://" URL, e.g.
+// "sqlite:///var/lib/timelines/events.db" or "sqlite://events.db" for a
+// path relative to the working directory, or "sqlite://:memory:" for an
+// ephemeral in-process database (handy for tests and CI).
+func NewSQLiteFromURL(databaseURL string) (*DB, error) {
+	path, err := sqlitePathFromURL(databaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Connecting to SQLite database at %s...", path)
+
+	sqlDB, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening sqlite database: %w", err)
+	}
+
+	// SQLite only allows one writer at a time; a single connection avoids
+	// "database is locked" errors under concurrent access from this process.
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := sqlDB.Ping(); err != nil {
+		return nil, fmt.Errorf("error connecting to sqlite database: %w", err)
+	}
+
+	if _, err := sqlDB.Exec(`PRAGMA foreign_keys = ON`); err != nil {
+		return nil, fmt.Errorf("error enabling sqlite foreign keys: %w", err)
+	}
+
+	if err := applySQLiteSchema(sqlDB); err != nil {
+		return nil, fmt.Errorf("error applying sqlite schema: %w", err)
+	}
+
+	return &DB{DB: sqlDB, stmts: newStmtCache()}, nil
+}
+
+// sqlitePathFromURL extracts the filesystem path (or ":memory:") a
+// "sqlite://" URL points at.
+func sqlitePathFromURL(databaseURL string) (string, error) {
+	parsed, err := url.Parse(databaseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid sqlite URL: %w", err)
+	}
+
+	path := parsed.Host + parsed.Path
+	if path == "" || path == ":memory:" {
+		return ":memory:", nil
+	}
+	return path, nil
+}
@@ -0,0 +1,568 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/timeline/backend/internal/models"
+)
+
+// SQLiteEventRepository is the SQLite-backed implementation of Store, for
+// embedded / offline deployments that can't run a Postgres server. It covers
+// the same EventStore/SourceStore/RelationshipStore surface as
+// EventRepository, with SQLite's dialect differences isolated here:
+//   - "?" placeholders instead of "$1", "$2", ...
+//   - LIKE instead of plainto_tsquery for Search
+//   - timeline_seconds round-tripped as TEXT instead of NUMERIC(38,0)
+//   - relationship_count maintained in Go, since there's no plpgsql trigger
+//   - event IDs are generated here (uuid.NewString) rather than left to a
+//     DEFAULT gen_random_uuid() column, since SQLite has no such builtin
+type SQLiteEventRepository struct {
+	db *DB
+}
+
+// NewSQLiteEventRepository creates a new SQLite-backed event repository.
+func NewSQLiteEventRepository(db *DB) *SQLiteEventRepository {
+	return &SQLiteEventRepository{db: db}
+}
+
+// bigBangToEpoch mirrors EventRepository.createWith's Big Bang offset so
+// timeline_seconds means the same thing regardless of backend.
+const bigBangToEpoch int64 = 435494878264400000
+
+func (r *SQLiteEventRepository) Create(req models.CreateEventRequest, userID *string) (*models.Event, error) {
+	id := uuid.NewString()
+	timelineSeconds := decimal.NewFromInt(req.UnixSeconds + bigBangToEpoch).String()
+
+	_, err := r.db.Exec(
+		`INSERT INTO events (
+			id, timeline_seconds, unix_seconds, unix_nanos, precision_level, uncertainty_range,
+			title, description, category, created_by_user_id, image_url
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, timelineSeconds, req.UnixSeconds, req.UnixNanos, req.PrecisionLevel, req.UncertaintyRange,
+		req.Title, req.Description, req.Category, userID, req.ImageURL,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	return r.GetByID(id)
+}
+
+func (r *SQLiteEventRepository) GetByID(id string) (*models.Event, error) {
+	event := &models.Event{}
+	err := r.db.QueryRow(
+		`SELECT id, timeline_seconds, unix_seconds, unix_nanos, precision_level, uncertainty_range,
+		        title, description, category, importance_score, related_event_id, relationship_count,
+		        location_count, created_at, updated_at, created_by_user_id, image_url
+		 FROM events
+		 WHERE id = ?`,
+		id,
+	).Scan(
+		&event.ID, &event.TimelineSeconds, &event.UnixSeconds, &event.UnixNanos, &event.PrecisionLevel,
+		&event.UncertaintyRange, &event.Title, &event.Description, &event.Category, &event.ImportanceScore,
+		&event.RelatedEventID, &event.RelationshipCount, &event.LocationCount, &event.CreatedAt,
+		&event.UpdatedAt, &event.CreatedByUserID, &event.ImageURL,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("event not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	return event, nil
+}
+
+func (r *SQLiteEventRepository) List(params models.EventQueryParams) ([]models.Event, error) {
+	query := `
+		SELECT id, timeline_seconds, unix_seconds, unix_nanos, precision_level, uncertainty_range,
+		       title, description, category, importance_score, related_event_id, relationship_count,
+		       location_count, created_at, updated_at, created_by_user_id, image_url
+		FROM events
+		WHERE 1=1
+	`
+
+	args := []interface{}{}
+
+	if params.StartSeconds != nil {
+		query += " AND unix_seconds >= ?"
+		args = append(args, *params.StartSeconds)
+	}
+
+	if params.EndSeconds != nil {
+		query += " AND unix_seconds <= ?"
+		args = append(args, *params.EndSeconds)
+	}
+
+	if params.Category != nil {
+		// No category_closure table in the SQLite schema yet, so
+		// IncludeDescendants is not honored here -- exact match only.
+		query += " AND category = ?"
+		args = append(args, *params.Category)
+	}
+
+	if params.MinImportance != nil {
+		query += " AND importance_score >= ?"
+		args = append(args, *params.MinImportance)
+	}
+
+	if params.Search != nil && *params.Search != "" {
+		query += " AND title LIKE ?"
+		args = append(args, "%"+*params.Search+"%")
+	}
+
+	if params.After != nil && *params.After != "" {
+		afterSeconds, afterNanos, afterID, err := DecodeCursor(*params.After)
+		if err != nil {
+			return nil, fmt.Errorf("invalid after cursor: %w", err)
+		}
+		query += " AND (unix_seconds, unix_nanos, id) > (?, ?, ?)"
+		args = append(args, afterSeconds, afterNanos, afterID)
+	}
+
+	if params.Before != nil && *params.Before != "" {
+		beforeSeconds, beforeNanos, beforeID, err := DecodeCursor(*params.Before)
+		if err != nil {
+			return nil, fmt.Errorf("invalid before cursor: %w", err)
+		}
+		query += " AND (unix_seconds, unix_nanos, id) < (?, ?, ?)"
+		args = append(args, beforeSeconds, beforeNanos, beforeID)
+	}
+
+	// Paging backward ("before") needs the rows immediately preceding the
+	// cursor, not the earliest rows in the whole table, so it must walk the
+	// triple DESC under the predicate and take the nearest N; we reverse the
+	// scanned rows below to restore ascending order in the response. Mirrors
+	// EventRepository.List's Postgres equivalent.
+	pagingBackward := params.Before != nil && *params.Before != "" && (params.After == nil || *params.After == "")
+	if pagingBackward {
+		query += " ORDER BY unix_seconds DESC, unix_nanos DESC, id DESC"
+	} else {
+		query += " ORDER BY unix_seconds ASC, unix_nanos ASC, id ASC"
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+	query += " LIMIT ?"
+	args = append(args, limit)
+
+	if params.After == nil && params.Before == nil && params.Offset > 0 {
+		query += " OFFSET ?"
+		args = append(args, params.Offset)
+	}
+
+	events, err := r.scanEvents(r.db.Query(query, args...))
+	if err != nil {
+		return nil, err
+	}
+
+	if pagingBackward {
+		for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+			events[i], events[j] = events[j], events[i]
+		}
+	}
+
+	return events, nil
+}
+
+func (r *SQLiteEventRepository) scanEvents(rows *sql.Rows, err error) ([]models.Event, error) {
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.Event{}
+	for rows.Next() {
+		event := models.Event{}
+		err := rows.Scan(
+			&event.ID, &event.TimelineSeconds, &event.UnixSeconds, &event.UnixNanos, &event.PrecisionLevel,
+			&event.UncertaintyRange, &event.Title, &event.Description, &event.Category, &event.ImportanceScore,
+			&event.RelatedEventID, &event.RelationshipCount, &event.LocationCount, &event.CreatedAt,
+			&event.UpdatedAt, &event.CreatedByUserID, &event.ImageURL,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func (r *SQLiteEventRepository) Update(id string, req models.UpdateEventRequest) (*models.Event, error) {
+	updates := []string{}
+	args := []interface{}{}
+
+	if req.UnixSeconds != nil {
+		updates = append(updates, "unix_seconds = ?")
+		args = append(args, *req.UnixSeconds)
+	}
+	if req.UnixNanos != nil {
+		updates = append(updates, "unix_nanos = ?")
+		args = append(args, *req.UnixNanos)
+	}
+	if req.PrecisionLevel != nil {
+		updates = append(updates, "precision_level = ?")
+		args = append(args, *req.PrecisionLevel)
+	}
+	if req.UncertaintyRange != nil {
+		updates = append(updates, "uncertainty_range = ?")
+		args = append(args, *req.UncertaintyRange)
+	}
+	if req.Title != nil {
+		updates = append(updates, "title = ?")
+		args = append(args, *req.Title)
+	}
+	if req.Description != nil {
+		updates = append(updates, "description = ?")
+		args = append(args, *req.Description)
+	}
+	if req.Category != nil {
+		updates = append(updates, "category = ?")
+		args = append(args, *req.Category)
+	}
+	if req.ImageURL != nil {
+		updates = append(updates, "image_url = ?")
+		args = append(args, *req.ImageURL)
+	}
+
+	if len(updates) == 0 {
+		return r.GetByID(id)
+	}
+
+	updates = append(updates, "updated_at = CURRENT_TIMESTAMP")
+	args = append(args, id)
+
+	result, err := r.db.Exec(
+		fmt.Sprintf("UPDATE events SET %s WHERE id = ?", strings.Join(updates, ", ")),
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update event: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm event update: %w", err)
+	}
+	if affected == 0 {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	return r.GetByID(id)
+}
+
+func (r *SQLiteEventRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM events WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete event: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("event not found")
+	}
+
+	return nil
+}
+
+func (r *SQLiteEventRepository) GetSourcesByEventID(eventID string) ([]*models.EventSource, error) {
+	sources, err := r.getSources(`event_id = ?`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
+
+func (r *SQLiteEventRepository) GetSourcesByEventIDs(eventIDs []string) (map[string][]*models.EventSource, error) {
+	if len(eventIDs) == 0 {
+		return make(map[string][]*models.EventSource), nil
+	}
+
+	placeholders := make([]string, len(eventIDs))
+	args := make([]interface{}, len(eventIDs))
+	for i, id := range eventIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	sources, err := r.getSources(fmt.Sprintf("event_id IN (%s)", strings.Join(placeholders, ", ")), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	sourcesMap := make(map[string][]*models.EventSource)
+	for _, source := range sources {
+		sourcesMap[source.EventID] = append(sourcesMap[source.EventID], source)
+	}
+	return sourcesMap, nil
+}
+
+func (r *SQLiteEventRepository) getSources(where string, args ...interface{}) ([]*models.EventSource, error) {
+	rows, err := r.db.Query(
+		fmt.Sprintf(`SELECT id, event_id, source_type, title, url, citation, credibility_score, added_by_user_id, created_at
+		 FROM event_sources
+		 WHERE %s
+		 ORDER BY event_id, created_at DESC, id`, where),
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event sources: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []*models.EventSource
+	for rows.Next() {
+		source := &models.EventSource{}
+		if err := rows.Scan(
+			&source.ID, &source.EventID, &source.SourceType, &source.Title, &source.URL,
+			&source.Citation, &source.CredibilityScore, &source.AddedByUserID, &source.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan event source: %w", err)
+		}
+		sources = append(sources, source)
+	}
+
+	return sources, rows.Err()
+}
+
+// CreateRelationship links two events. relationship_count is bumped on both
+// events inline rather than by a database trigger (SQLite has no plpgsql).
+func (r *SQLiteEventRepository) CreateRelationship(a, b string, relType string, weight decimal.Decimal, desc string) (*models.EventRelationship, error) {
+	if !models.RelationshipType(relType).IsValid() {
+		return nil, fmt.Errorf("invalid relationship type: %s", relType)
+	}
+
+	var description *string
+	if desc != "" {
+		description = &desc
+	}
+
+	id := uuid.NewString()
+	_, err := r.db.Exec(
+		`INSERT INTO event_relationships (id, event_id_a, event_id_b, relationship_type, weight, relationship_description)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		id, a, b, relType, weight.String(), description,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create relationship: %w", err)
+	}
+
+	if _, err := r.db.Exec(`UPDATE events SET relationship_count = relationship_count + 1 WHERE id IN (?, ?)`, a, b); err != nil {
+		return nil, fmt.Errorf("failed to update relationship counts: %w", err)
+	}
+
+	return r.getRelationshipByID(id)
+}
+
+func (r *SQLiteEventRepository) getRelationshipByID(id string) (*models.EventRelationship, error) {
+	rel := &models.EventRelationship{}
+	var weight string
+	err := r.db.QueryRow(
+		`SELECT id, event_id_a, event_id_b, relationship_type, weight, relationship_description, created_at
+		 FROM event_relationships WHERE id = ?`,
+		id,
+	).Scan(&rel.ID, &rel.EventIDA, &rel.EventIDB, &rel.RelationshipType, &weight, &rel.Description, &rel.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relationship: %w", err)
+	}
+
+	rel.Weight, err = decimal.NewFromString(weight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse relationship weight: %w", err)
+	}
+
+	return rel, nil
+}
+
+func (r *SQLiteEventRepository) DeleteRelationship(id string) error {
+	var a, b string
+	if err := r.db.QueryRow(`SELECT event_id_a, event_id_b FROM event_relationships WHERE id = ?`, id).Scan(&a, &b); err != nil {
+		if err == sql.ErrNoRows {
+			return sql.ErrNoRows
+		}
+		return fmt.Errorf("failed to look up relationship: %w", err)
+	}
+
+	if _, err := r.db.Exec(`DELETE FROM event_relationships WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete relationship: %w", err)
+	}
+
+	if _, err := r.db.Exec(`UPDATE events SET relationship_count = relationship_count - 1 WHERE id IN (?, ?)`, a, b); err != nil {
+		return fmt.Errorf("failed to update relationship counts: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteEventRepository) GetRelationshipsByType(eventID string, relType string, limit, offset int) ([]*models.EventRelationship, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := r.db.Query(
+		`SELECT id, event_id_a, event_id_b, relationship_type, weight, relationship_description, created_at
+		 FROM event_relationships
+		 WHERE (event_id_a = ? OR event_id_b = ?) AND relationship_type = ?
+		 ORDER BY weight DESC, id ASC
+		 LIMIT ? OFFSET ?`,
+		eventID, eventID, relType, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relationships by type: %w", err)
+	}
+	defer rows.Close()
+
+	var relationships []*models.EventRelationship
+	for rows.Next() {
+		rel := &models.EventRelationship{}
+		var weight string
+		if err := rows.Scan(&rel.ID, &rel.EventIDA, &rel.EventIDB, &rel.RelationshipType, &weight, &rel.Description, &rel.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event relationship: %w", err)
+		}
+		rel.Weight, err = decimal.NewFromString(weight)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse relationship weight: %w", err)
+		}
+		relationships = append(relationships, rel)
+	}
+
+	return relationships, rows.Err()
+}
+
+func (r *SQLiteEventRepository) GetRelationshipsByEventIDs(eventIDs []string) (map[string][]*models.EventRelationship, error) {
+	if len(eventIDs) == 0 {
+		return make(map[string][]*models.EventRelationship), nil
+	}
+
+	placeholders := make([]string, len(eventIDs))
+	args := make([]interface{}, 2*len(eventIDs))
+	for i, id := range eventIDs {
+		placeholders[i] = "?"
+		args[i] = id
+		args[len(eventIDs)+i] = id
+	}
+
+	rows, err := r.db.Query(
+		fmt.Sprintf(`SELECT id, event_id_a, event_id_b, relationship_type, weight, relationship_description, created_at
+		 FROM event_relationships
+		 WHERE event_id_a IN (%s) OR event_id_b IN (%s)
+		 ORDER BY weight DESC, id ASC`, strings.Join(placeholders, ", "), strings.Join(placeholders, ", ")),
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event relationships: %w", err)
+	}
+	defer rows.Close()
+
+	relationshipsMap := make(map[string][]*models.EventRelationship)
+	for rows.Next() {
+		rel := &models.EventRelationship{}
+		var weight string
+		if err := rows.Scan(&rel.ID, &rel.EventIDA, &rel.EventIDB, &rel.RelationshipType, &weight, &rel.Description, &rel.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event relationship: %w", err)
+		}
+		rel.Weight, err = decimal.NewFromString(weight)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse relationship weight: %w", err)
+		}
+		relationshipsMap[rel.EventIDA] = append(relationshipsMap[rel.EventIDA], rel)
+		relationshipsMap[rel.EventIDB] = append(relationshipsMap[rel.EventIDB], rel)
+	}
+
+	return relationshipsMap, rows.Err()
+}
+
+// GetRelatedEventsRecursive walks event_relationships breadth-first in Go
+// instead of via a recursive CTE: SQLite's WITH RECURSIVE has no array type
+// to track the visited path, and reimplementing that with a JSON column would
+// be more code than just walking it here, where maxRelationshipTraversalDepth
+// already bounds the work.
+func (r *SQLiteEventRepository) GetRelatedEventsRecursive(eventID string, relType string, maxDepth int) ([]models.Event, error) {
+	if maxDepth <= 0 || maxDepth > maxRelationshipTraversalDepth {
+		maxDepth = maxRelationshipTraversalDepth
+	}
+
+	visited := map[string]bool{eventID: true}
+	frontier := []string{eventID}
+
+	for depth := 0; depth < maxDepth && len(frontier) > 0; depth++ {
+		placeholders := make([]string, len(frontier))
+		args := make([]interface{}, 2*len(frontier)+1)
+		for i, id := range frontier {
+			placeholders[i] = "?"
+			args[i] = id
+			args[len(frontier)+i] = id
+		}
+		args[2*len(frontier)] = relType
+
+		rows, err := r.db.Query(
+			fmt.Sprintf(`SELECT event_id_a, event_id_b FROM event_relationships
+			 WHERE (event_id_a IN (%s) OR event_id_b IN (%s)) AND relationship_type = ?`,
+				strings.Join(placeholders, ", "), strings.Join(placeholders, ", ")),
+			args...,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to traverse relationships: %w", err)
+		}
+
+		var next []string
+		for rows.Next() {
+			var a, b string
+			if err := rows.Scan(&a, &b); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan relationship edge: %w", err)
+			}
+			for _, id := range []string{a, b} {
+				if !visited[id] {
+					visited[id] = true
+					next = append(next, id)
+				}
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("error iterating relationship edges: %w", err)
+		}
+
+		frontier = next
+	}
+
+	delete(visited, eventID)
+	if len(visited) == 0 {
+		return []models.Event{}, nil
+	}
+
+	ids := make([]string, 0, len(visited))
+	for id := range visited {
+		ids = append(ids, id)
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, timeline_seconds, unix_seconds, unix_nanos, precision_level, uncertainty_range,
+		       title, description, category, importance_score, related_event_id, relationship_count,
+		       location_count, created_at, updated_at, created_by_user_id, image_url
+		FROM events
+		WHERE id IN (%s)
+		ORDER BY unix_seconds ASC, unix_nanos ASC, id ASC
+	`, strings.Join(placeholders, ", "))
+
+	return r.scanEvents(r.db.Query(query, args...))
+}
@@ -0,0 +1,74 @@
+package db
+
+import "database/sql"
+
+// sqliteSchema is a condensed, single-file mirror of migrations 0001-0006 for
+// the SQLite backend. SQLite has no migration runner in this codebase, so the
+// embedded/offline deployment applies its whole schema up front; Postgres
+// keeps using the golang-migrate files in backend/migrations as before.
+//
+// Notable dialect substitutions versus the Postgres schema:
+//   - timeline_seconds is TEXT, not NUMERIC(38,0); SQLiteEventRepository
+//     round-trips it through decimal.Decimal as a string.
+//   - No triggers/plpgsql: relationship_count is maintained in Go instead of
+//     a maintain_event_relationship_count() trigger.
+//   - No tsvector/FTS index; List() falls back to a LIKE search over title.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS events (
+	id TEXT PRIMARY KEY,
+	timeline_seconds TEXT NOT NULL,
+	unix_seconds INTEGER NOT NULL,
+	unix_nanos INTEGER NOT NULL DEFAULT 0,
+	precision_level TEXT NOT NULL,
+	uncertainty_range INTEGER,
+	title TEXT NOT NULL,
+	description TEXT,
+	category TEXT,
+	image_url TEXT,
+	importance_score INTEGER NOT NULL DEFAULT 0,
+	related_event_id TEXT,
+	relationship_count INTEGER NOT NULL DEFAULT 0,
+	location_count INTEGER NOT NULL DEFAULT 0,
+	created_by_user_id TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_unix_seconds ON events (unix_seconds, unix_nanos, id);
+CREATE INDEX IF NOT EXISTS idx_events_category ON events (category);
+CREATE INDEX IF NOT EXISTS idx_events_created_by_user_id ON events (created_by_user_id);
+
+CREATE TABLE IF NOT EXISTS event_sources (
+	id TEXT PRIMARY KEY,
+	event_id TEXT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+	source_type TEXT NOT NULL,
+	title TEXT,
+	url TEXT,
+	citation TEXT,
+	credibility_score INTEGER NOT NULL DEFAULT 0,
+	added_by_user_id TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_sources_event_id ON event_sources (event_id);
+
+CREATE TABLE IF NOT EXISTS event_relationships (
+	id TEXT PRIMARY KEY,
+	event_id_a TEXT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+	event_id_b TEXT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+	relationship_type TEXT NOT NULL,
+	weight TEXT NOT NULL DEFAULT '1',
+	relationship_description TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_relationships_a ON event_relationships (event_id_a);
+CREATE INDEX IF NOT EXISTS idx_event_relationships_b ON event_relationships (event_id_b);
+`
+
+// applySQLiteSchema creates the tables and indexes used by
+// SQLiteEventRepository if they don't already exist.
+func applySQLiteSchema(sqlDB *sql.DB) error {
+	_, err := sqlDB.Exec(sqliteSchema)
+	return err
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+)
+
+// stmtCache lazily Prepares and caches *sql.Stmt per canonical query
+// signature. EventRepository.List and updateWith build SQL text that varies
+// with which filter/update fields are present -- without this, every
+// distinct combination was a brand-new unprepared query string, defeating
+// Postgres's plan cache and giving pg_stat_statements one noisy entry per
+// combination instead of one per shape. There are at most 2^k variants for k
+// optional fields, and only the combinations a caller actually hits ever get
+// Prepared.
+type stmtCache struct {
+	mu    sync.RWMutex
+	stmts map[string]*sql.Stmt
+}
+
+func newStmtCache() *stmtCache {
+	return &stmtCache{stmts: make(map[string]*sql.Stmt)}
+}
+
+// getOrPrepare returns the cached *sql.Stmt for signature, preparing query
+// against conn the first time signature is seen.
+func (c *stmtCache) getOrPrepare(conn *sql.DB, signature, query string) (*sql.Stmt, error) {
+	c.mu.RLock()
+	stmt, ok := c.stmts[signature]
+	c.mu.RUnlock()
+	if ok {
+		return stmt, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stmt, ok := c.stmts[signature]; ok {
+		return stmt, nil
+	}
+
+	stmt, err := conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	c.stmts[signature] = stmt
+	return stmt, nil
+}
+
+// PreparedQuery runs query as a cached prepared statement keyed by
+// signature, preparing it on first use and reusing it on every later call
+// with the same signature (calls with the same signature build the same SQL
+// text and differ only in their args).
+func (d *DB) PreparedQuery(signature, query string, args ...interface{}) (*sql.Rows, error) {
+	stmt, err := d.stmts.getOrPrepare(d.DB, signature, query)
+	if err != nil {
+		return nil, err
+	}
+	return stmt.Query(args...)
+}
+
+// PreparedStmtTx adapts the cached statement for signature to run inside tx,
+// for callers (e.g. UpdateTx) that need the statement cache's plan reuse
+// within a caller-managed transaction.
+func (d *DB) PreparedStmtTx(tx *sql.Tx, signature, query string) (*sql.Stmt, error) {
+	stmt, err := d.stmts.getOrPrepare(d.DB, signature, query)
+	if err != nil {
+		return nil, err
+	}
+	return tx.Stmt(stmt), nil
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/timeline/backend/internal/models"
+)
+
+// EventStore abstracts event CRUD and listing so the API layer doesn't care
+// whether it's backed by Postgres (EventRepository) or SQLite
+// (SQLiteEventRepository). The Tx-suffixed batch-endpoint helpers and the
+// selector-specific List variants (ListForUser, ListRelatedTo, ...) stay
+// concrete-type-only for now; they're convenience wrappers around the same
+// underlying queries, not part of the portable core.
+type EventStore interface {
+	Create(req models.CreateEventRequest, userID *string) (*models.Event, error)
+	GetByID(id string) (*models.Event, error)
+	List(params models.EventQueryParams) ([]models.Event, error)
+	Update(id string, req models.UpdateEventRequest) (*models.Event, error)
+	Delete(id string) error
+}
+
+// SourceStore abstracts citation/reference lookups for events.
+type SourceStore interface {
+	GetSourcesByEventID(eventID string) ([]*models.EventSource, error)
+	GetSourcesByEventIDs(eventIDs []string) (map[string][]*models.EventSource, error)
+}
+
+// RelationshipStore abstracts the typed event_relationships subsystem (see
+// EventRepository.CreateRelationship and friends).
+type RelationshipStore interface {
+	CreateRelationship(a, b string, relType string, weight decimal.Decimal, desc string) (*models.EventRelationship, error)
+	DeleteRelationship(id string) error
+	GetRelationshipsByType(eventID string, relType string, limit, offset int) ([]*models.EventRelationship, error)
+	GetRelationshipsByEventIDs(eventIDs []string) (map[string][]*models.EventRelationship, error)
+	GetRelatedEventsRecursive(eventID string, relType string, maxDepth int) ([]models.Event, error)
+}
+
+// Store bundles the three interfaces a storage backend must implement.
+// EventRepository (Postgres) and SQLiteEventRepository both satisfy it.
+type Store interface {
+	EventStore
+	SourceStore
+	RelationshipStore
+}
+
+var (
+	_ Store = (*EventRepository)(nil)
+	_ Store = (*SQLiteEventRepository)(nil)
+)
@@ -0,0 +1,82 @@
+package enrich
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+
+	"github.com/timeline/backend/internal/db"
+)
+
+// roundCoord rounds a coordinate to 5 decimal places (~1.1m precision), the
+// key granularity used by the geocode_cache table.
+func roundCoord(v float64) float64 {
+	return math.Round(v*1e5) / 1e5
+}
+
+// Cache wraps the geocode_cache table, keyed by provider + rounded lat/lon.
+type Cache struct {
+	db *db.DB
+}
+
+func NewCache(database *db.DB) *Cache {
+	return &Cache{db: database}
+}
+
+// Get returns a cached result for (provider, lat, lon), or nil if there is no entry.
+func (c *Cache) Get(ctx context.Context, provider string, lat, lon float64) (*Result, error) {
+	query := `
+		SELECT location_name, country_code, admin1_code, admin2_code, rollup_geojson
+		FROM geocode_cache
+		WHERE provider = $1 AND lat = $2 AND lon = $3
+	`
+
+	var (
+		result Result
+		admin1 sql.NullString
+		admin2 sql.NullString
+		rollup sql.NullString
+	)
+
+	err := c.db.QueryRowContext(ctx, query, provider, roundCoord(lat), roundCoord(lon)).Scan(
+		&result.LocationName, &result.CountryCode, &admin1, &admin2, &rollup,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("geocode cache lookup failed: %w", err)
+	}
+
+	result.Admin1Code = admin1.String
+	result.Admin2Code = admin2.String
+	result.RollupGeoJSON = rollup.String
+
+	return &result, nil
+}
+
+// Put upserts a result into the cache.
+func (c *Cache) Put(ctx context.Context, provider string, lat, lon float64, result *Result) error {
+	query := `
+		INSERT INTO geocode_cache (provider, lat, lon, location_name, country_code, admin1_code, admin2_code, rollup_geojson, cached_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		ON CONFLICT (provider, lat, lon) DO UPDATE SET
+			location_name = EXCLUDED.location_name,
+			country_code = EXCLUDED.country_code,
+			admin1_code = EXCLUDED.admin1_code,
+			admin2_code = EXCLUDED.admin2_code,
+			rollup_geojson = EXCLUDED.rollup_geojson,
+			cached_at = NOW()
+	`
+
+	_, err := c.db.ExecContext(ctx, query,
+		provider, roundCoord(lat), roundCoord(lon),
+		result.LocationName, result.CountryCode, result.Admin1Code, result.Admin2Code, result.RollupGeoJSON,
+	)
+	if err != nil {
+		return fmt.Errorf("geocode cache write failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+// Package enrich fills in place names and administrative-boundary metadata for
+// event locations that only have a raw point or polygon, via reverse geocoding.
+package enrich
+
+import "context"
+
+// Result is what a Geocoder returns for a single lat/lon lookup.
+type Result struct {
+	LocationName string
+	CountryCode  string
+	Admin1Code   string
+	Admin2Code   string
+
+	// RollupGeoJSON is the geometry of the smallest matched administrative
+	// boundary (e.g. the admin2 polygon), used to snap point locations to a
+	// recognizable area on the map.
+	RollupGeoJSON string
+}
+
+// Geocoder resolves a lat/lon pair to a place name and administrative codes.
+// Nominatim and a local Natural Earth lookup are the two initial implementations.
+type Geocoder interface {
+	Name() string
+	ReverseGeocode(ctx context.Context, lat, lon float64) (*Result, error)
+}
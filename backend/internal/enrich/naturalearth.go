@@ -0,0 +1,59 @@
+package enrich
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/timeline/backend/internal/db"
+)
+
+// NaturalEarthGeocoder resolves lat/lon against a Natural Earth admin-boundary
+// layer loaded into PostGIS, for offline use when no internet access is available.
+type NaturalEarthGeocoder struct {
+	db *db.DB
+}
+
+// NewNaturalEarthGeocoder creates a geocoder backed by the `natural_earth_admin`
+// table, expected to be populated from the Natural Earth admin0/admin1 shapefiles.
+func NewNaturalEarthGeocoder(database *db.DB) *NaturalEarthGeocoder {
+	return &NaturalEarthGeocoder{db: database}
+}
+
+func (g *NaturalEarthGeocoder) Name() string {
+	return "natural_earth"
+}
+
+func (g *NaturalEarthGeocoder) ReverseGeocode(ctx context.Context, lat, lon float64) (*Result, error) {
+	query := `
+		SELECT name, country_code, admin1_code, admin2_code, ST_AsGeoJSON(geom)
+		FROM natural_earth_admin
+		WHERE ST_Contains(geom, ST_SetSRID(ST_MakePoint($1, $2), 4326))
+		ORDER BY admin_level DESC
+		LIMIT 1
+	`
+
+	var (
+		name         string
+		countryCode  string
+		admin1       sql.NullString
+		admin2       sql.NullString
+		rollupGeoJSON string
+	)
+
+	err := g.db.QueryRowContext(ctx, query, lon, lat).Scan(&name, &countryCode, &admin1, &admin2, &rollupGeoJSON)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no natural earth boundary contains point (%f, %f)", lat, lon)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("natural earth lookup failed: %w", err)
+	}
+
+	return &Result{
+		LocationName:  name,
+		CountryCode:   countryCode,
+		Admin1Code:    admin1.String,
+		Admin2Code:    admin2.String,
+		RollupGeoJSON: rollupGeoJSON,
+	}, nil
+}
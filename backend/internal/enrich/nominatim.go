@@ -0,0 +1,88 @@
+package enrich
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// NominatimGeocoder reverse-geocodes against the public (or a self-hosted) Nominatim API.
+type NominatimGeocoder struct {
+	baseURL    string
+	userAgent  string
+	httpClient *http.Client
+	limiter    *TokenBucket
+}
+
+// NewNominatimGeocoder creates a Nominatim-backed geocoder rate limited to Nominatim's
+// documented usage policy of 1 request/second.
+func NewNominatimGeocoder(baseURL, userAgent string) *NominatimGeocoder {
+	return &NominatimGeocoder{
+		baseURL:    baseURL,
+		userAgent:  userAgent,
+		httpClient: &http.Client{},
+		limiter:    NewTokenBucket(1, 1),
+	}
+}
+
+func (g *NominatimGeocoder) Name() string {
+	return "nominatim"
+}
+
+type nominatimResponse struct {
+	DisplayName string `json:"display_name"`
+	Address     struct {
+		CountryCode string `json:"country_code"`
+		State       string `json:"state"`
+		County      string `json:"county"`
+	} `json:"address"`
+	GeoJSON json.RawMessage `json:"geojson"`
+}
+
+func (g *NominatimGeocoder) ReverseGeocode(ctx context.Context, lat, lon float64) (*Result, error) {
+	if err := g.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	reqURL := fmt.Sprintf("%s/reverse?%s", g.baseURL, url.Values{
+		"lat":         {fmt.Sprintf("%f", lat)},
+		"lon":         {fmt.Sprintf("%f", lon)},
+		"format":      {"jsonv2"},
+		"polygon_geojson": {"1"},
+	}.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", g.userAgent)
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("nominatim reverse geocode failed: %d", resp.StatusCode)
+	}
+
+	var parsed nominatimResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode nominatim response: %w", err)
+	}
+
+	result := &Result{
+		LocationName: parsed.DisplayName,
+		CountryCode:  parsed.Address.CountryCode,
+		Admin1Code:   parsed.Address.State,
+		Admin2Code:   parsed.Address.County,
+	}
+	if len(parsed.GeoJSON) > 0 {
+		result.RollupGeoJSON = string(parsed.GeoJSON)
+	}
+
+	return result, nil
+}
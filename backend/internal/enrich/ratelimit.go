@@ -0,0 +1,63 @@
+package enrich
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenBucket is a simple per-provider rate limiter: it holds up to `burst`
+// tokens and refills at `refillRate` tokens per second.
+type TokenBucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	burst      float64
+	refillRate float64
+	lastRefill time.Time
+}
+
+// NewTokenBucket creates a bucket that starts full.
+func NewTokenBucket(refillRate float64, burst int) *TokenBucket {
+	return &TokenBucket{
+		tokens:     float64(burst),
+		burst:      float64(burst),
+		refillRate: refillRate,
+		lastRefill: time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or the context is cancelled.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		if b.takeToken() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+func (b *TokenBucket) takeToken() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.lastRefill = now
+
+	b.tokens += elapsed * b.refillRate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
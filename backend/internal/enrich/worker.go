@@ -0,0 +1,48 @@
+package enrich
+
+import (
+	"context"
+	"fmt"
+)
+
+// Worker enriches event_locations rows by reverse-geocoding their point/polygon
+// through a chain of Geocoder providers, caching results along the way.
+type Worker struct {
+	providers []Geocoder
+	cache     *Cache
+}
+
+// NewWorker builds a worker that tries providers in order until one succeeds.
+func NewWorker(cache *Cache, providers ...Geocoder) *Worker {
+	return &Worker{providers: providers, cache: cache}
+}
+
+// Enrich resolves lat/lon to a Result, checking the cache for each provider
+// before falling through to the next one.
+func (w *Worker) Enrich(ctx context.Context, lat, lon float64) (*Result, error) {
+	var lastErr error
+
+	for _, provider := range w.providers {
+		if cached, err := w.cache.Get(ctx, provider.Name(), lat, lon); err == nil && cached != nil {
+			return cached, nil
+		}
+
+		result, err := provider.ReverseGeocode(ctx, lat, lon)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if err := w.cache.Put(ctx, provider.Name(), lat, lon, result); err != nil {
+			// Caching is best-effort; a failed write shouldn't fail the enrichment.
+			lastErr = err
+		}
+
+		return result, nil
+	}
+
+	if lastErr != nil {
+		return nil, fmt.Errorf("all geocoders failed: %w", lastErr)
+	}
+	return nil, fmt.Errorf("no geocoder providers configured")
+}
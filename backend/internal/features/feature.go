@@ -0,0 +1,55 @@
+// Package features lets speculative, opt-in APIs register themselves onto the
+// core router without the core handlers needing to know about them, in the
+// spirit of Matrix's MSC opt-in modules. Enable features via config:
+//
+//	features:
+//	  enabled: ["graph_traversal", "webhooks"]
+package features
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timeline/backend/internal/db"
+)
+
+// Feature is a self-contained, independently-enableable slice of the API.
+type Feature interface {
+	// Name is the config key used to enable this feature (e.g. "graph_traversal").
+	Name() string
+
+	// Register wires the feature's routes/middleware onto base.
+	Register(base *gin.RouterGroup, repo *db.EventRepository, database *db.DB) error
+}
+
+// Registry holds every feature the binary knows how to build, and enables a
+// config-selected subset of them.
+type Registry struct {
+	available map[string]Feature
+}
+
+// NewRegistry builds a registry from the given features, keyed by their Name().
+func NewRegistry(available ...Feature) *Registry {
+	r := &Registry{available: make(map[string]Feature, len(available))}
+	for _, f := range available {
+		r.available[f.Name()] = f
+	}
+	return r
+}
+
+// Enable registers each named feature's routes onto base, in order. Called
+// from main after the core routes are set up. Returns an error naming the
+// first unknown feature or registration failure, rather than enabling a
+// partial set silently.
+func (r *Registry) Enable(base *gin.RouterGroup, enabled []string, repo *db.EventRepository, database *db.DB) error {
+	for _, name := range enabled {
+		feature, ok := r.available[name]
+		if !ok {
+			return fmt.Errorf("unknown feature: %s", name)
+		}
+		if err := feature.Register(base, repo, database); err != nil {
+			return fmt.Errorf("failed to register feature %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package features
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"github.com/timeline/backend/internal/db"
+)
+
+// maxTraversalDepth caps the BFS depth regardless of what the caller requests,
+// so a malicious or accidental ?depth=10000 can't turn a graph walk into a
+// full-table scan.
+const maxTraversalDepth = 10
+
+// GraphTraversalFeature adds GET /api/events/:id/neighbors?depth=N, a BFS over
+// event_relationships with cycle detection and a max-depth cap.
+type GraphTraversalFeature struct{}
+
+func (f *GraphTraversalFeature) Name() string {
+	return "graph_traversal"
+}
+
+func (f *GraphTraversalFeature) Register(base *gin.RouterGroup, repo *db.EventRepository, database *db.DB) error {
+	base.GET("/events/:id/neighbors", func(c *gin.Context) {
+		f.getNeighbors(c, database)
+	})
+	return nil
+}
+
+type neighborEdge struct {
+	EventID          string `json:"event_id"`
+	RelationshipType string `json:"relationship_type"`
+	Depth            int    `json:"depth"`
+}
+
+func (f *GraphTraversalFeature) getNeighbors(c *gin.Context, database *db.DB) {
+	eventID := c.Param("id")
+
+	depth := 1
+	if raw := c.Query("depth"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "depth must be a positive integer"})
+			return
+		}
+		depth = parsed
+	}
+	if depth > maxTraversalDepth {
+		depth = maxTraversalDepth
+	}
+
+	visited := map[string]bool{eventID: true}
+	frontier := []string{eventID}
+	var neighbors []neighborEdge
+
+	for level := 1; level <= depth && len(frontier) > 0; level++ {
+		rows, err := database.Query(
+			`SELECT event_id_a, event_id_b, relationship_type FROM event_relationships
+			 WHERE event_id_a = ANY($1) OR event_id_b = ANY($1)`,
+			pq.Array(frontier),
+		)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query relationships"})
+			return
+		}
+
+		var next []string
+		for rows.Next() {
+			var a, b, relType string
+			if err := rows.Scan(&a, &b, &relType); err != nil {
+				continue
+			}
+
+			for _, candidate := range []string{a, b} {
+				if visited[candidate] {
+					continue
+				}
+				if !isInFrontier(frontier, a, b, candidate) {
+					continue
+				}
+				visited[candidate] = true
+				next = append(next, candidate)
+				neighbors = append(neighbors, neighborEdge{EventID: candidate, RelationshipType: relType, Depth: level})
+			}
+		}
+		rows.Close()
+
+		frontier = next
+	}
+
+	c.JSON(http.StatusOK, gin.H{"neighbors": neighbors})
+}
+
+// isInFrontier reports whether candidate is reachable from the current
+// frontier via the edge (a, b) -- i.e. the other endpoint of the edge is in
+// the current frontier, so candidate is a genuine new hop rather than a
+// same-level edge between two already-queued nodes.
+func isInFrontier(frontier []string, a, b, candidate string) bool {
+	other := a
+	if candidate == a {
+		other = b
+	}
+	for _, f := range frontier {
+		if f == other {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package features
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/timeline/backend/internal/db"
+)
+
+// WebhooksFeature adds POST /api/webhooks for registering a callback URL, and
+// dispatches event lifecycle notifications to every registered webhook with
+// an HMAC-SHA256 signature header so receivers can verify authenticity.
+type WebhooksFeature struct {
+	mu        sync.RWMutex
+	endpoints []webhookEndpoint
+	client    *http.Client
+}
+
+type webhookEndpoint struct {
+	URL    string
+	Secret string
+}
+
+// NewWebhooksFeature creates an empty webhooks feature; endpoints are added via POST /api/webhooks.
+func NewWebhooksFeature() *WebhooksFeature {
+	return &WebhooksFeature{client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (f *WebhooksFeature) Name() string {
+	return "webhooks"
+}
+
+func (f *WebhooksFeature) Register(base *gin.RouterGroup, repo *db.EventRepository, database *db.DB) error {
+	base.POST("/webhooks", f.registerWebhook)
+	return nil
+}
+
+type registerWebhookRequest struct {
+	URL    string `json:"url" binding:"required,url"`
+	Secret string `json:"secret" binding:"required,min=16"`
+}
+
+func (f *WebhooksFeature) registerWebhook(c *gin.Context) {
+	var req registerWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	f.mu.Lock()
+	f.endpoints = append(f.endpoints, webhookEndpoint{URL: req.URL, Secret: req.Secret})
+	f.mu.Unlock()
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Webhook registered"})
+}
+
+// Dispatch fans an event lifecycle notification out to every registered
+// webhook, signing each request body with that endpoint's secret. Best-effort:
+// delivery failures are not retried here and don't block the caller beyond
+// the per-request timeout on f.client.
+func (f *WebhooksFeature) Dispatch(eventType string, payload interface{}) {
+	f.mu.RLock()
+	endpoints := make([]webhookEndpoint, len(f.endpoints))
+	copy(endpoints, f.endpoints)
+	f.mu.RUnlock()
+
+	body, err := json.Marshal(gin.H{"type": eventType, "data": payload})
+	if err != nil {
+		return
+	}
+
+	for _, endpoint := range endpoints {
+		go f.deliver(endpoint, body)
+	}
+}
+
+func (f *WebhooksFeature) deliver(endpoint webhookEndpoint, body []byte) {
+	mac := hmac.New(sha256.New, []byte(endpoint.Secret))
+	mac.Write(body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	req, err := http.NewRequest(http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Timelines-Signature", fmt.Sprintf("sha256=%s", signature))
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
@@ -1,28 +1,49 @@
 package middleware
 
 import (
+	"database/sql"
 	"net/http"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/timeline/backend/internal/db"
 	"github.com/timeline/backend/internal/models"
 )
 
-// AuthMiddleware extracts user from Authorization header (Bearer token format: user_id)
-// For now, this is a simple bearer token format where the token is the user ID
-// In production, you'd validate JWT tokens or session IDs here
+// MaxSessionLifetime caps how long a session may be refreshed for, regardless
+// of individual access token TTLs.
+const MaxSessionLifetime = 30 * 24 * time.Hour
+
+// legacyBearerDeadline is the cutoff after which the old "Bearer <user-id>"
+// format is rejected. Controlled by AUTH_LEGACY_BEARER_UNTIL (RFC3339); if unset
+// or unparsable, legacy tokens are rejected (the safe default).
+func legacyBearerDeadline() (time.Time, bool) {
+	raw := os.Getenv("AUTH_LEGACY_BEARER_UNTIL")
+	if raw == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// AuthMiddleware validates the bearer token as a signed JWT whose `jti` maps to
+// a row in `sessions`, and attaches the authenticated user (and claims) to the
+// request context. Falls back to the legacy "Bearer <user-id>" format only
+// while AUTH_LEGACY_BEARER_UNTIL is set and in the future.
 func AuthMiddleware(database *db.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			// No auth provided, continue as unauthenticated
 			c.Set("user", nil)
 			c.Next()
 			return
 		}
 
-		// Extract token from "Bearer <token>" format
 		parts := strings.SplitN(authHeader, " ", 2)
 		if len(parts) != 2 || parts[0] != "Bearer" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
@@ -30,32 +51,48 @@ func AuthMiddleware(database *db.DB) gin.HandlerFunc {
 			return
 		}
 
-		userID := strings.TrimSpace(parts[1])
-		if userID == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Empty user ID"})
+		token := strings.TrimSpace(parts[1])
+		if token == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Empty token"})
+			c.Abort()
+			return
+		}
+
+		claims, err := ParseAndVerifyToken(token)
+		if err != nil {
+			if legacyUser, ok := tryLegacyBearer(database, token); ok {
+				c.Set("user", legacyUser)
+				c.Next()
+				return
+			}
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			c.Abort()
+			return
+		}
+
+		var revokedAt sql.NullTime
+		var expiresAt time.Time
+		err = database.QueryRow(
+			"SELECT expires_at, revoked_at FROM sessions WHERE jti = $1 AND user_id = $2",
+			claims.JTI, claims.UserID,
+		).Scan(&expiresAt, &revokedAt)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Session not found"})
+			c.Abort()
+			return
+		}
+		if revokedAt.Valid {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+			c.Abort()
+			return
+		}
+		if time.Now().After(expiresAt) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has expired"})
 			c.Abort()
 			return
 		}
 
-		// Fetch user from database
-		var dbUser models.User
-		var lastLoginAt interface{}
-		err := database.QueryRow(
-			"SELECT id, x_user_id, username, display_name, avatar_url, bio, role, is_active, is_twitter_verified, created_at, last_login_at FROM users WHERE id = $1",
-			userID,
-		).Scan(
-			&dbUser.ID,
-			&dbUser.XUserID,
-			&dbUser.Username,
-			&dbUser.DisplayName,
-			&dbUser.AvatarURL,
-			&dbUser.Bio,
-			&dbUser.Role,
-			&dbUser.IsActive,
-			&dbUser.IsTwitterVerified,
-			&dbUser.CreatedAt,
-			&lastLoginAt,
-		)
+		dbUser, err := loadUser(database, claims.UserID)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
 			c.Abort()
@@ -68,12 +105,53 @@ func AuthMiddleware(database *db.DB) gin.HandlerFunc {
 			return
 		}
 
-		// Store user in context for later use
-		c.Set("user", &dbUser)
+		c.Set("user", dbUser)
+		c.Set("claims", claims)
 		c.Next()
 	}
 }
 
+// tryLegacyBearer accepts the pre-JWT "Bearer <user-id>" format while the
+// migration window (AUTH_LEGACY_BEARER_UNTIL) is open.
+func tryLegacyBearer(database *db.DB, token string) (*models.User, bool) {
+	deadline, enabled := legacyBearerDeadline()
+	if !enabled || time.Now().After(deadline) {
+		return nil, false
+	}
+
+	dbUser, err := loadUser(database, token)
+	if err != nil || !dbUser.IsActive {
+		return nil, false
+	}
+
+	return dbUser, true
+}
+
+func loadUser(database *db.DB, userID string) (*models.User, error) {
+	var dbUser models.User
+	var lastLoginAt interface{}
+	err := database.QueryRow(
+		"SELECT id, x_user_id, username, display_name, avatar_url, bio, role, is_active, is_twitter_verified, created_at, last_login_at FROM users WHERE id = $1",
+		userID,
+	).Scan(
+		&dbUser.ID,
+		&dbUser.XUserID,
+		&dbUser.Username,
+		&dbUser.DisplayName,
+		&dbUser.AvatarURL,
+		&dbUser.Bio,
+		&dbUser.Role,
+		&dbUser.IsActive,
+		&dbUser.IsTwitterVerified,
+		&dbUser.CreatedAt,
+		&lastLoginAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &dbUser, nil
+}
+
 // RequireAuth middleware ensures user is authenticated
 func RequireAuth(c *gin.Context) {
 	user, exists := c.Get("user")
@@ -107,6 +185,32 @@ func RequireTwitterVerified(c *gin.Context) {
 	}
 }
 
+// RequireRole returns a middleware factory that ensures the authenticated user
+// has the given role (e.g. "admin", "moderator").
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, exists := c.Get("user")
+		if !exists || user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			c.Abort()
+			return
+		}
+
+		dbUser, ok := user.(*models.User)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user object"})
+			c.Abort()
+			return
+		}
+
+		if dbUser.Role != role {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Requires " + role + " role"})
+			c.Abort()
+			return
+		}
+	}
+}
+
 // GetAuthUser retrieves the authenticated user from context
 func GetAuthUser(c *gin.Context) *models.User {
 	user, exists := c.Get("user")
@@ -121,3 +225,20 @@ func GetAuthUser(c *gin.Context) *models.User {
 
 	return dbUser
 }
+
+// GetAuthClaims retrieves the verified JWT claims from context, for handlers that
+// need scopes without hitting the database. Returns nil for unauthenticated or
+// legacy-bearer requests (which have no claims).
+func GetAuthClaims(c *gin.Context) *AuthClaims {
+	claims, exists := c.Get("claims")
+	if !exists {
+		return nil
+	}
+
+	authClaims, ok := claims.(*AuthClaims)
+	if !ok {
+		return nil
+	}
+
+	return authClaims
+}
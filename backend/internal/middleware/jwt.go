@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// signingKeys holds the active HS256 keys, keyed by `kid` so tokens signed
+// under a previous key keep validating until they expire. Loaded once from
+// JWT_SIGNING_KEYS, a comma-separated "kid:secret" list, e.g. "2024-06:abc,2024-01:def".
+// The first entry is used to sign new tokens; the rest are accepted for validation only.
+var signingKeys map[string]string
+var currentKid string
+
+func init() {
+	signingKeys = make(map[string]string)
+
+	raw := os.Getenv("JWT_SIGNING_KEYS")
+	if raw == "" {
+		return
+	}
+
+	for i, entry := range strings.Split(raw, ",") {
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		kid, secret := parts[0], parts[1]
+		signingKeys[kid] = secret
+		if i == 0 {
+			currentKid = kid
+		}
+	}
+}
+
+// AuthClaims is the JWT payload issued for authenticated sessions.
+type AuthClaims struct {
+	UserID string   `json:"sub"`
+	JTI    string   `json:"jti"` // maps to the sessions row backing this token
+	Scopes []string `json:"scopes,omitempty"`
+	IssuedAt  int64 `json:"iat"`
+	NotBefore int64 `json:"nbf"`
+	ExpiresAt int64 `json:"exp"`
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+func base64URLEncode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func base64URLDecode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+// IssueToken signs a new JWT for userID/jti with the given TTL using the current signing key.
+func IssueToken(userID, jti string, scopes []string, ttl time.Duration) (string, error) {
+	if currentKid == "" {
+		return "", fmt.Errorf("no JWT signing key configured (set JWT_SIGNING_KEYS)")
+	}
+
+	now := time.Now()
+	claims := AuthClaims{
+		UserID:    userID,
+		JTI:       jti,
+		Scopes:    scopes,
+		IssuedAt:  now.Unix(),
+		NotBefore: now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+
+	header := jwtHeader{Alg: "HS256", Kid: currentKid, Typ: "JWT"}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64URLEncode(headerJSON) + "." + base64URLEncode(claimsJSON)
+	signature := signHS256(signingInput, signingKeys[currentKid])
+
+	return signingInput + "." + signature, nil
+}
+
+func signHS256(input, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(input))
+	return base64URLEncode(mac.Sum(nil))
+}
+
+// ParseAndVerifyToken validates a JWT's signature (with the key named by its kid
+// header) and its iat/nbf/exp claims, returning the decoded claims on success.
+func ParseAndVerifyToken(token string) (*AuthClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed token")
+	}
+
+	headerJSON, err := base64URLDecode(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token header")
+	}
+
+	var header jwtHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, fmt.Errorf("malformed token header")
+	}
+
+	secret, ok := signingKeys[header.Kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %s", header.Kid)
+	}
+
+	signingInput := parts[0] + "." + parts[1]
+	expectedSig := signHS256(signingInput, secret)
+	if !hmac.Equal([]byte(expectedSig), []byte(parts[2])) {
+		return nil, fmt.Errorf("invalid token signature")
+	}
+
+	claimsJSON, err := base64URLDecode(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token claims")
+	}
+
+	var claims AuthClaims
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, fmt.Errorf("malformed token claims")
+	}
+
+	now := time.Now().Unix()
+	if claims.IssuedAt > now {
+		return nil, fmt.Errorf("token issued in the future")
+	}
+	if claims.NotBefore > now {
+		return nil, fmt.Errorf("token not yet valid")
+	}
+	if claims.ExpiresAt < now {
+		return nil, fmt.Errorf("token expired")
+	}
+
+	return &claims, nil
+}
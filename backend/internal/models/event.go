@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/timeline/backend/internal/utils"
 )
 
@@ -25,6 +26,11 @@ type Event struct {
 
 	ImportanceScore int `json:"importance_score" db:"importance_score"`
 
+	// ExternalID identifies this event in an upstream source (e.g. a Wikidata
+	// QID or a row ID from a historical CSV dump), so re-imports can upsert
+	// instead of creating duplicates. Nil for events created directly by users.
+	ExternalID *string `json:"external_id,omitempty" db:"external_id"`
+
 	// Relationships
 	RelatedEventID *string `json:"related_event_id,omitempty" db:"related_event_id"`
 
@@ -44,6 +50,7 @@ type CreateEventRequest struct {
 	Description      *string               `json:"description,omitempty"`
 	Category         *string               `json:"category,omitempty"`
 	ImageURL         *string               `json:"image_url,omitempty"`
+	ExternalID       *string               `json:"external_id,omitempty"`
 }
 
 // UpdateEventRequest represents the request to update an event
@@ -65,15 +72,25 @@ type EventQueryParams struct {
 	EndSeconds   *int64 `form:"end"`
 
 	// Filtering
-	Category        *string `form:"category"`
-	MinImportance   *int    `form:"min_importance"`
+	Category            *string `form:"category"`
+	IncludeDescendants  bool    `form:"include_descendants"`
+	MinImportance       *int    `form:"min_importance"`
 
-	// Pagination
-	Limit  int `form:"limit" binding:"omitempty,min=1,max=100000"`
-	Offset int `form:"offset" binding:"omitempty,min=0"`
+	// Pagination. After/Before are opaque keyset cursors encoding
+	// (unix_seconds, unix_nanos, id); Limit/Offset remain supported for callers
+	// that haven't migrated off offset-based paging.
+	Limit  int     `form:"limit" binding:"omitempty,min=1,max=100000"`
+	Offset int     `form:"offset" binding:"omitempty,min=0"`
+	After  *string `form:"after"`
+	Before *string `form:"before"`
 
 	// Search
 	Search *string `form:"search"`
+
+	// Selector narrows results to a personalized view: "all" (default), "mine",
+	// "voted", "sourced_by_me", or "related_to" (paired with RelatedTo).
+	Selector  *string `form:"selector"`
+	RelatedTo *string `form:"related_to"`
 }
 
 // EventResponse represents an event with computed fields
@@ -86,6 +103,7 @@ type EventResponse struct {
 	SourceCount   int `json:"source_count"`
 	DiscussionCount int `json:"discussion_count"`
 	Sources       []*EventSource `json:"sources,omitempty"`
+	Relationships []*EventRelationship `json:"relationships,omitempty"`
 }
 
 // VoteStats represents aggregated vote statistics
@@ -159,6 +177,69 @@ type Vote struct {
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// RelationshipType is a closed enum of the relationship_type values the
+// event_relationships.relationship_type CHECK constraint allows, modeled
+// after Matrix's m.relates_to relation types.
+type RelationshipType string
+
+const (
+	RelationshipCausedBy    RelationshipType = "caused_by"
+	RelationshipPartOf      RelationshipType = "part_of"
+	RelationshipReferences  RelationshipType = "references"
+	RelationshipContradicts RelationshipType = "contradicts"
+	RelationshipAnnotates   RelationshipType = "annotates"
+	RelationshipThread      RelationshipType = "thread"
+)
+
+// ValidRelationshipTypes enumerates every value the CHECK constraint accepts.
+var ValidRelationshipTypes = []RelationshipType{
+	RelationshipCausedBy,
+	RelationshipPartOf,
+	RelationshipReferences,
+	RelationshipContradicts,
+	RelationshipAnnotates,
+	RelationshipThread,
+}
+
+// IsValid reports whether t is one of ValidRelationshipTypes.
+func (t RelationshipType) IsValid() bool {
+	for _, v := range ValidRelationshipTypes {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// EventRelationship is a typed, weighted, directed edge between two events
+// (EventIDA -> EventIDB), analogous to Matrix's m.relates_to: RelationshipType
+// says how B relates to A, Weight ranks relationships of the same type, and
+// Description is an optional free-text annotation.
+type EventRelationship struct {
+	ID               string            `json:"id" db:"id"`
+	EventIDA         string            `json:"event_id_a" db:"event_id_a"`
+	EventIDB         string            `json:"event_id_b" db:"event_id_b"`
+	RelationshipType RelationshipType  `json:"relationship_type" db:"relationship_type"`
+	Weight           decimal.Decimal   `json:"weight" db:"weight"`
+	Description      *string           `json:"relationship_description,omitempty" db:"relationship_description"`
+	CreatedAt        time.Time         `json:"created_at" db:"created_at"`
+}
+
+// EventRevision is one append-only entry in an event's audit trail: the
+// fields that changed between the previous and new snapshot, who changed
+// them, and why. Diff is raw JSON (field name -> {"old": ..., "new": ...})
+// rather than a typed struct, since the set of changed fields varies per
+// revision.
+type EventRevision struct {
+	ID              string    `json:"id" db:"id"`
+	EventID         string    `json:"event_id" db:"event_id"`
+	Revision        int       `json:"revision" db:"revision"`
+	ChangedAt       time.Time `json:"changed_at" db:"changed_at"`
+	ChangedByUserID *string   `json:"changed_by_user_id,omitempty" db:"changed_by_user_id"`
+	Diff            string    `json:"diff" db:"diff"`
+	Reason          *string   `json:"reason,omitempty" db:"reason"`
+}
+
 // EventSource represents a citation or reference for an event
 type EventSource struct {
 	ID               string     `json:"id" db:"id"`
@@ -172,6 +253,16 @@ type EventSource struct {
 	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
 }
 
+// Bucket is one bucket of EventRepository.ListAggregated's histogram: a
+// count of every event in [StartSeconds, EndSeconds), independent of how many
+// of those events are actually returned as representatives.
+type Bucket struct {
+	Index        int   `json:"index"`
+	StartSeconds int64 `json:"start_seconds"`
+	EndSeconds   int64 `json:"end_seconds"`
+	Count        int   `json:"count"`
+}
+
 // ZoomPreset represents a predefined zoom level
 type ZoomPreset struct {
 	ID                     string          `json:"id" db:"id"`
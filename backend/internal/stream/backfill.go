@@ -0,0 +1,85 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const recentSearchURL = "https://api.twitter.com/2/tweets/search/recent"
+
+// backfillRequestTimeout bounds the one-off gap-fill request made on
+// reconnect; it must not block the caller's backoff/reconnect loop for long.
+const backfillRequestTimeout = 15 * time.Second
+
+// backfillSince recovers tweets posted while the stream was disconnected, by
+// running a recent-search query matching the current rule set with
+// since_id=lastID, and pushing anything found onto Tweets in the same shape
+// the live stream would have delivered it in.
+func (c *Client) backfillSince(ctx context.Context, lastID string) error {
+	ctx, cancel := context.WithTimeout(ctx, backfillRequestTimeout)
+	defer cancel()
+
+	handles, err := c.activeHandles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load active handles: %w", err)
+	}
+	if len(handles) == 0 {
+		return nil
+	}
+
+	clauses := make([]string, len(handles))
+	for i, handle := range handles {
+		clauses[i] = "from:" + handle
+	}
+	query := strings.Join(clauses, " OR ")
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("since_id", lastID)
+	params.Set("tweet.fields", "created_at,author_id")
+	params.Set("max_results", "100")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, recentSearchURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("recent search failed: %d", resp.StatusCode)
+	}
+
+	var parsed struct {
+		Data []TwitterStatus `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return err
+	}
+
+	// The API returns newest-first; send oldest-first, matching stream order.
+	for i, j := 0, len(parsed.Data)-1; i < j; i, j = i+1, j-1 {
+		parsed.Data[i], parsed.Data[j] = parsed.Data[j], parsed.Data[i]
+	}
+
+	for i := range parsed.Data {
+		status := parsed.Data[i]
+		select {
+		case c.Tweets <- &status:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const rulesURL = streamURL + "/rules"
+
+// maxRuleLength is the filtered stream's per-rule character cap.
+const maxRuleLength = 512
+
+// rulesRequestTimeout bounds each rule-management call; unlike the stream
+// connection itself, these are ordinary request/response round trips.
+const rulesRequestTimeout = 10 * time.Second
+
+type streamRule struct {
+	ID    string `json:"id,omitempty"`
+	Value string `json:"value"`
+	Tag   string `json:"tag,omitempty"`
+}
+
+type rulesResponse struct {
+	Data []streamRule `json:"data"`
+}
+
+// syncRules recomputes the filtered-stream rules from every active, linked
+// Twitter handle and replaces whatever rules Twitter currently has
+// registered, so the stream always tracks exactly "who's signed in" rather
+// than a rule set that drifts as users link or unlink their account.
+func (c *Client) syncRules(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, rulesRequestTimeout)
+	defer cancel()
+
+	handles, err := c.activeHandles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load active handles: %w", err)
+	}
+
+	existing, err := c.currentRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load existing rules: %w", err)
+	}
+	if len(existing) > 0 {
+		ids := make([]string, len(existing))
+		for i, r := range existing {
+			ids[i] = r.ID
+		}
+		if err := c.deleteRules(ctx, ids); err != nil {
+			return fmt.Errorf("failed to delete existing rules: %w", err)
+		}
+	}
+
+	if len(handles) == 0 {
+		return nil
+	}
+	return c.addRules(ctx, rulesFromHandles(handles))
+}
+
+// activeHandles returns the Twitter handle of every active user with a
+// linked Twitter identity.
+func (c *Client) activeHandles(ctx context.Context) ([]string, error) {
+	rows, err := c.db.QueryContext(ctx,
+		`SELECT u.username
+		 FROM user_identities i
+		 JOIN users u ON u.id = i.user_id
+		 WHERE i.provider = 'twitter' AND u.is_active AND u.username IS NOT NULL`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var handles []string
+	for rows.Next() {
+		var handle string
+		if err := rows.Scan(&handle); err != nil {
+			return nil, err
+		}
+		handles = append(handles, handle)
+	}
+	return handles, rows.Err()
+}
+
+// rulesFromHandles packs handles into as few rules as possible: each rule is
+// a "from:a OR from:b OR ..." clause kept under maxRuleLength, since the
+// filtered stream caps both the number of rules and each rule's length on
+// most access tiers.
+func rulesFromHandles(handles []string) []streamRule {
+	var rules []streamRule
+	var clauses []string
+	value := ""
+
+	flush := func() {
+		if len(clauses) > 0 {
+			rules = append(rules, streamRule{Value: value, Tag: "linked-users"})
+			clauses = nil
+			value = ""
+		}
+	}
+
+	for _, handle := range handles {
+		clause := "from:" + handle
+		candidate := clause
+		if value != "" {
+			candidate = value + " OR " + clause
+		}
+		if len(candidate) > maxRuleLength {
+			flush()
+			candidate = clause
+		}
+		value = candidate
+		clauses = append(clauses, clause)
+	}
+	flush()
+
+	return rules
+}
+
+func (c *Client) currentRules(ctx context.Context) ([]streamRule, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rulesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	var parsed rulesResponse
+	if err := c.doRulesRequest(req, &parsed); err != nil {
+		return nil, err
+	}
+	return parsed.Data, nil
+}
+
+func (c *Client) addRules(ctx context.Context, rules []streamRule) error {
+	return c.postRules(ctx, map[string]interface{}{"add": rules})
+}
+
+func (c *Client) deleteRules(ctx context.Context, ids []string) error {
+	return c.postRules(ctx, map[string]interface{}{
+		"delete": map[string][]string{"ids": ids},
+	})
+}
+
+func (c *Client) postRules(ctx context.Context, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rulesURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.doRulesRequest(req, nil)
+}
+
+func (c *Client) doRulesRequest(req *http.Request, out interface{}) error {
+	req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("stream rules request failed: %d - %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
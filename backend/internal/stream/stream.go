@@ -0,0 +1,259 @@
+// Package stream complements the poll-based internal/daemon.TweetPersister
+// with a push-based ingester modeled on Twitter's v2 filtered stream: a
+// long-lived GET that receives matching tweets as they're posted instead of
+// waiting for the next poll tick.
+package stream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/timeline/backend/internal/db"
+)
+
+const streamURL = "https://api.twitter.com/2/tweets/search/stream"
+
+// initialBackoff and maxBackoff bound the exponential backoff applied after a
+// disconnect, per Twitter's guidance for 420/429/5xx responses.
+const (
+	initialBackoff = 1 * time.Second
+	maxBackoff     = 5 * time.Minute
+)
+
+// keepAliveTimeout is longer than Twitter's ~20s keep-alive newline interval;
+// if the stream goes quiet for this long, treat it as a dead connection.
+const keepAliveTimeout = 30 * time.Second
+
+// TwitterStatus is a single tweet as delivered by the filtered stream.
+type TwitterStatus struct {
+	ID        string    `json:"id"`
+	Text      string    `json:"text"`
+	AuthorID  string    `json:"author_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// streamEnvelope is the per-line shape the v2 filtered stream sends:
+// {"data": {...}, "matching_rules": [...]}.
+type streamEnvelope struct {
+	Data          TwitterStatus `json:"data"`
+	MatchingRules []struct {
+		ID  string `json:"id"`
+		Tag string `json:"tag"`
+	} `json:"matching_rules"`
+}
+
+// Client consumes the filtered stream and fans out tweets and errors,
+// mirroring the classic streaming-client shape: callers range over Tweets and
+// Errors rather than polling the Client for state.
+type Client struct {
+	db          *db.DB
+	bearerToken string
+	httpClient  *http.Client
+
+	Tweets chan *TwitterStatus
+	Errors chan error
+
+	reload      chan struct{}
+	lastTweetID string
+}
+
+// NewClient builds a Client. bearerToken is the App-only OAuth2 bearer token
+// used to manage rules and open the stream (distinct from the per-user
+// access tokens TweetPersister refreshes, since stream management is an
+// app-level, not user-level, operation). It reads from the same
+// user_identities table TweetPersister does to compute its rules, so a
+// handle becomes trackable the moment it's linked.
+func NewClient(database *db.DB, bearerToken string) *Client {
+	return &Client{
+		db:          database,
+		bearerToken: bearerToken,
+		httpClient:  &http.Client{}, // no timeout: this is a long-lived connection
+		Tweets:      make(chan *TwitterStatus, 256),
+		Errors:      make(chan error, 16),
+		reload:      make(chan struct{}, 1),
+	}
+}
+
+// Reload forces an out-of-band rule recomputation, so a newly linked Twitter
+// handle starts being tracked without waiting for the next disconnect.
+// Non-blocking: if a reload is already pending, this is a no-op.
+func (c *Client) Reload() {
+	select {
+	case c.reload <- struct{}{}:
+	default:
+	}
+}
+
+// Run connects to the filtered stream and blocks until ctx is cancelled,
+// reconnecting with exponential backoff on error. Intended to be started as
+// `go client.Run(ctx)` alongside TweetPersister.
+func (c *Client) Run(ctx context.Context) {
+	defer close(c.Tweets)
+	defer close(c.Errors)
+
+	if err := c.syncRules(ctx); err != nil {
+		c.emitError(fmt.Errorf("initial rule sync failed: %w", err))
+	}
+
+	backoff := initialBackoff
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.reload:
+			if err := c.syncRules(ctx); err != nil {
+				c.emitError(fmt.Errorf("rule reload failed: %w", err))
+			}
+			continue
+		default:
+		}
+
+		decodedAny, err := c.connect(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			c.emitError(err)
+		}
+
+		if decodedAny {
+			backoff = initialBackoff
+		} else {
+			backoff = nextBackoff(backoff, err)
+		}
+		log.Printf("stream: reconnecting in %s", backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// connect opens the stream and reads from it until it errors out or ctx is
+// cancelled. decodedAny reports whether at least one line was successfully
+// decoded, so Run can reset the backoff on a connection that proved healthy
+// instead of ratcheting it up regardless. The filtered stream itself has no
+// since_id cursoring (it's a push feed, not a query), so any tweets posted
+// during the backoff window are recovered with a one-off gap-fill search
+// before the long-lived connection is reopened.
+func (c *Client) connect(ctx context.Context) (decodedAny bool, err error) {
+	if c.lastTweetID != "" {
+		if err := c.backfillSince(ctx, c.lastTweetID); err != nil {
+			c.emitError(fmt.Errorf("gap-fill after reconnect failed: %w", err))
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		streamURL+"?tweet.fields=created_at,author_id", nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, &statusError{code: resp.StatusCode}
+	}
+
+	lines := make(chan []byte)
+	scanErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			// Bytes() is reused on the next Scan(), so copy before handing
+			// the line to the select loop below.
+			line := append([]byte(nil), scanner.Bytes()...)
+			lines <- line
+		}
+		scanErr <- scanner.Err()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return decodedAny, ctx.Err()
+
+		case <-time.After(keepAliveTimeout):
+			return decodedAny, fmt.Errorf("stream: no data for %s, treating connection as dead", keepAliveTimeout)
+
+		case <-c.reload:
+			if err := c.syncRules(ctx); err != nil {
+				c.emitError(fmt.Errorf("rule reload failed: %w", err))
+			}
+
+		case line, ok := <-lines:
+			if !ok {
+				if err := <-scanErr; err != nil {
+					return decodedAny, err
+				}
+				return decodedAny, fmt.Errorf("stream closed by server")
+			}
+			if len(line) == 0 {
+				continue // keep-alive newline
+			}
+
+			var envelope streamEnvelope
+			if err := json.Unmarshal(line, &envelope); err != nil {
+				c.emitError(fmt.Errorf("malformed stream payload: %w", err))
+				continue
+			}
+			decodedAny = true
+
+			status := envelope.Data
+			c.lastTweetID = status.ID
+			select {
+			case c.Tweets <- &status:
+			case <-ctx.Done():
+				return decodedAny, ctx.Err()
+			}
+		}
+	}
+}
+
+// statusError carries the HTTP status code of a failed connection attempt.
+// nextBackoff applies the same doubling to every failure and does not look
+// at the code.
+type statusError struct{ code int }
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("stream connection failed: status %d", e.code)
+}
+
+// nextBackoff doubles prev, capped at maxBackoff, and adds up to 20% jitter
+// so many replicas reconnecting after the same outage don't thunder-herd.
+// A nil err (clean disconnect) still backs off, since immediately
+// reconnecting to a server that just closed the stream rarely helps.
+func nextBackoff(prev time.Duration, err error) time.Duration {
+	next := prev * 2
+	if next > maxBackoff {
+		next = maxBackoff
+	}
+	if next < initialBackoff {
+		next = initialBackoff
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(next) / 5))
+	return next + jitter
+}
+
+func (c *Client) emitError(err error) {
+	select {
+	case c.Errors <- err:
+	default:
+		log.Printf("stream: error channel full, dropping: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
 )
 
@@ -13,13 +14,15 @@ type CategoryMetadata struct {
 	Icon        string `json:"icon,omitempty"`
 }
 
+// CategoryGroup is a node in the category tree. Children is recursive so the
+// tree can represent arbitrary depth, not just a fixed two-level hierarchy.
 type CategoryGroup struct {
-	ID          string               `json:"id"`
-	Name        string               `json:"name"`
-	Description string               `json:"description"`
-	Color       string               `json:"color"`
-	Icon        string               `json:"icon,omitempty"`
-	Children    []CategoryMetadata   `json:"children"`
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Color       string          `json:"color"`
+	Icon        string          `json:"icon,omitempty"`
+	Children    []CategoryGroup `json:"children"`
 }
 
 type CategoriesConfig struct {
@@ -60,9 +63,11 @@ func loadCategoriesFromDB() {
 	}
 	defer rows.Close()
 
-	// Build tree structure
-	parents := make(map[string]*CategoryGroup)
-	children := make(map[string][]CategoryMetadata)
+	// Build an adjacency list so the tree can be assembled to arbitrary depth,
+	// not just two levels.
+	nodes := make(map[string]*CategoryGroup)
+	childrenOf := make(map[string][]string)
+	var roots []string
 
 	for rows.Next() {
 		var id, name, description, color string
@@ -79,40 +84,47 @@ func loadCategoriesFromDB() {
 			iconStr = icon.String
 		}
 
-		if !parentID.Valid {
-			// This is a parent category
-			parents[id] = &CategoryGroup{
-				ID:          id,
-				Name:        name,
-				Description: description,
-				Color:       color,
-				Icon:        iconStr,
-				Children:    []CategoryMetadata{},
-			}
+		nodes[id] = &CategoryGroup{
+			ID:          id,
+			Name:        name,
+			Description: description,
+			Color:       color,
+			Icon:        iconStr,
+			Children:    []CategoryGroup{},
+		}
+
+		validCategories[id] = CategoryMetadata{
+			ID:          id,
+			Name:        name,
+			Description: description,
+			Color:       color,
+			Icon:        iconStr,
+		}
+
+		if parentID.Valid {
+			childrenOf[parentID.String] = append(childrenOf[parentID.String], id)
 		} else {
-			// This is a child category
-			child := CategoryMetadata{
-				ID:          id,
-				Name:        name,
-				Description: description,
-				Color:       color,
-				Icon:        iconStr,
-			}
-			children[parentID.String] = append(children[parentID.String], child)
-			validCategories[id] = child
+			roots = append(roots, id)
 		}
 	}
 
-	// Build final tree
-	categoriesTree = []CategoryGroup{}
-	for _, parent := range parents {
-		if childList, ok := children[parent.ID]; ok {
-			parent.Children = childList
+	categoriesTree = buildCategorySubtree(roots, nodes, childrenOf)
+
+	log.Printf("Loaded %d categories from database", len(validCategories))
+}
+
+// buildCategorySubtree recursively attaches children to build the full nested tree.
+func buildCategorySubtree(ids []string, nodes map[string]*CategoryGroup, childrenOf map[string][]string) []CategoryGroup {
+	tree := make([]CategoryGroup, 0, len(ids))
+	for _, id := range ids {
+		node := nodes[id]
+		if node == nil {
+			continue
 		}
-		categoriesTree = append(categoriesTree, *parent)
+		node.Children = buildCategorySubtree(childrenOf[id], nodes, childrenOf)
+		tree = append(tree, *node)
 	}
-
-	log.Printf("Loaded %d parent categories and %d child categories from database", len(parents), len(validCategories))
+	return tree
 }
 
 func populateDefaultCategories() {
@@ -151,3 +163,81 @@ func GetAllCategories() map[string]CategoryMetadata {
 func GetCategoriesTree() []CategoryGroup {
 	return categoriesTree
 }
+
+// GetDescendants returns the IDs of all descendants of categoryID (at any depth),
+// backed by the category_closure table so arbitrarily deep trees don't require
+// walking parent/child pointers in Go.
+func GetDescendants(categoryID string) ([]string, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database not set, call SetDB first")
+	}
+
+	rows, err := db.Query(
+		`SELECT descendant FROM category_closure WHERE ancestor = $1 AND depth > 0`,
+		categoryID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query category descendants: %w", err)
+	}
+	defer rows.Close()
+
+	var descendants []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan category descendant: %w", err)
+		}
+		descendants = append(descendants, id)
+	}
+
+	return descendants, nil
+}
+
+// IsDescendantOf reports whether child is a descendant of ancestor at any depth.
+func IsDescendantOf(child, ancestor string) (bool, error) {
+	if db == nil {
+		return false, fmt.Errorf("database not set, call SetDB first")
+	}
+
+	var exists bool
+	err := db.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM category_closure WHERE ancestor = $1 AND descendant = $2 AND depth > 0)`,
+		ancestor, child,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check category ancestry: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetCategoryPath returns the breadcrumb path from the root category down to
+// and including categoryID, ordered by depth descending (furthest ancestor first).
+func GetCategoryPath(categoryID string) ([]CategoryMetadata, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database not set, call SetDB first")
+	}
+
+	rows, err := db.Query(`
+		SELECT c.id, c.name, c.description, c.color, COALESCE(c.icon, '')
+		FROM category_closure cc
+		JOIN categories c ON c.id = cc.ancestor
+		WHERE cc.descendant = $1
+		ORDER BY cc.depth DESC
+	`, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query category path: %w", err)
+	}
+	defer rows.Close()
+
+	var path []CategoryMetadata
+	for rows.Next() {
+		var cat CategoryMetadata
+		if err := rows.Scan(&cat.ID, &cat.Name, &cat.Description, &cat.Color, &cat.Icon); err != nil {
+			return nil, fmt.Errorf("failed to scan category path entry: %w", err)
+		}
+		path = append(path, cat)
+	}
+
+	return path, nil
+}
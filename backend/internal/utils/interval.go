@@ -0,0 +1,231 @@
+package utils
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// decimalSqrt computes the square root of a non-negative decimal.Decimal using
+// Newton's method. decimal.Decimal has no native Sqrt, and float64 round-tripping
+// would reintroduce the precision loss this whole subsystem exists to avoid.
+func decimalSqrt(d decimal.Decimal) decimal.Decimal {
+	if d.LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero
+	}
+
+	// Seed the iteration from a float64 approximation; Newton's method converges
+	// quadratically so a handful of iterations is enough to reach decimal.DivisionPrecision.
+	guess := decimal.NewFromFloat(d.InexactFloat64()).Pow(decimal.NewFromFloat(0.5))
+	if guess.IsZero() {
+		guess = decimal.NewFromFloat(1)
+	}
+
+	two := decimal.NewFromInt(2)
+	for i := 0; i < 50; i++ {
+		next := guess.Add(d.Div(guess)).Div(two)
+		if next.Sub(guess).Abs().LessThan(decimal.New(1, -int32(decimal.DivisionPrecision))) {
+			return next
+		}
+		guess = next
+	}
+
+	return guess
+}
+
+// uncertaintyOrZero returns a TimelineTime's ± range, or zero if it has none.
+func uncertaintyOrZero(tt TimelineTime) decimal.Decimal {
+	if tt.UncertaintyRange == nil {
+		return decimal.Zero
+	}
+	return *tt.UncertaintyRange
+}
+
+// combineUncertainty propagates the uncertainty of two estimates into a combined one.
+// Independent measurements add in quadrature (sigma_sum = sqrt(s1^2 + s2^2));
+// correlated measurements (Correlated == true on both sides) add linearly.
+func combineUncertainty(a, b TimelineTime) decimal.Decimal {
+	s1 := uncertaintyOrZero(a)
+	s2 := uncertaintyOrZero(b)
+
+	if a.Correlated && b.Correlated {
+		return s1.Add(s2)
+	}
+
+	sumOfSquares := s1.Mul(s1).Add(s2.Mul(s2))
+	return decimalSqrt(sumOfSquares)
+}
+
+// Add returns a new TimelineTime advanced by delta seconds, propagating uncertainty.
+func (tt TimelineTime) Add(delta TimelineTime) TimelineTime {
+	result := tt
+	result.Seconds = tt.Seconds.Add(delta.Seconds)
+	combined := combineUncertainty(tt, delta)
+	if !combined.IsZero() {
+		result.UncertaintyRange = &combined
+	}
+	return result
+}
+
+// Sub returns a new TimelineTime offset backward by delta seconds, propagating uncertainty.
+func (tt TimelineTime) Sub(delta TimelineTime) TimelineTime {
+	result := tt
+	result.Seconds = tt.Seconds.Sub(delta.Seconds)
+	combined := combineUncertainty(tt, delta)
+	if !combined.IsZero() {
+		result.UncertaintyRange = &combined
+	}
+	return result
+}
+
+// lowerBound returns Seconds - UncertaintyRange (or Seconds if there is no uncertainty).
+func (tt TimelineTime) lowerBound() decimal.Decimal {
+	return tt.Seconds.Sub(uncertaintyOrZero(tt))
+}
+
+// upperBound returns Seconds + UncertaintyRange (or Seconds if there is no uncertainty).
+func (tt TimelineTime) upperBound() decimal.Decimal {
+	return tt.Seconds.Add(uncertaintyOrZero(tt))
+}
+
+// Contains reports whether other's uncertainty interval falls entirely within tt's.
+func (tt TimelineTime) Contains(other TimelineTime) bool {
+	return tt.lowerBound().LessThanOrEqual(other.lowerBound()) &&
+		tt.upperBound().GreaterThanOrEqual(other.upperBound())
+}
+
+// Overlaps reports whether tt and other's uncertainty intervals intersect, and
+// if so returns the size of the overlap in seconds.
+func (tt TimelineTime) Overlaps(other TimelineTime) (bool, decimal.Decimal) {
+	lo := maxDecimal(tt.lowerBound(), other.lowerBound())
+	hi := minDecimal(tt.upperBound(), other.upperBound())
+
+	if hi.LessThan(lo) {
+		return false, decimal.Zero
+	}
+	return true, hi.Sub(lo)
+}
+
+func maxDecimal(a, b decimal.Decimal) decimal.Decimal {
+	if a.GreaterThan(b) {
+		return a
+	}
+	return b
+}
+
+func minDecimal(a, b decimal.Decimal) decimal.Decimal {
+	if a.LessThan(b) {
+		return a
+	}
+	return b
+}
+
+// Allen's interval algebra, computed from each TimelineTime's [Seconds - UncertaintyRange, Seconds + UncertaintyRange] span.
+
+// Before reports whether tt's interval ends strictly before other's begins.
+func (tt TimelineTime) Before(other TimelineTime) bool {
+	return tt.upperBound().LessThan(other.lowerBound())
+}
+
+// After reports whether tt's interval begins strictly after other's ends (inverse of Before).
+func (tt TimelineTime) After(other TimelineTime) bool {
+	return other.Before(tt)
+}
+
+// Meets reports whether tt's interval ends exactly where other's begins.
+func (tt TimelineTime) Meets(other TimelineTime) bool {
+	return tt.upperBound().Equal(other.lowerBound())
+}
+
+// MetBy reports whether other meets tt (inverse of Meets).
+func (tt TimelineTime) MetBy(other TimelineTime) bool {
+	return other.Meets(tt)
+}
+
+// Overlaps13 reports the Allen "overlaps" relation: tt begins before other, and
+// the two intervals overlap, but tt also ends before other does.
+func (tt TimelineTime) Overlaps13(other TimelineTime) bool {
+	return tt.lowerBound().LessThan(other.lowerBound()) &&
+		other.lowerBound().LessThan(tt.upperBound()) &&
+		tt.upperBound().LessThan(other.upperBound())
+}
+
+// OverlappedBy13 is the inverse of Overlaps13.
+func (tt TimelineTime) OverlappedBy13(other TimelineTime) bool {
+	return other.Overlaps13(tt)
+}
+
+// Starts reports whether tt and other begin at the same point, but tt ends first.
+func (tt TimelineTime) Starts(other TimelineTime) bool {
+	return tt.lowerBound().Equal(other.lowerBound()) && tt.upperBound().LessThan(other.upperBound())
+}
+
+// StartedBy is the inverse of Starts.
+func (tt TimelineTime) StartedBy(other TimelineTime) bool {
+	return other.Starts(tt)
+}
+
+// During reports whether tt's interval is strictly contained within other's.
+func (tt TimelineTime) During(other TimelineTime) bool {
+	return other.lowerBound().LessThan(tt.lowerBound()) && tt.upperBound().LessThan(other.upperBound())
+}
+
+// Includes is the inverse of During.
+func (tt TimelineTime) Includes(other TimelineTime) bool {
+	return other.During(tt)
+}
+
+// Finishes reports whether tt and other end at the same point, but tt begins later.
+func (tt TimelineTime) Finishes(other TimelineTime) bool {
+	return tt.upperBound().Equal(other.upperBound()) && other.lowerBound().LessThan(tt.lowerBound())
+}
+
+// FinishedBy is the inverse of Finishes.
+func (tt TimelineTime) FinishedBy(other TimelineTime) bool {
+	return other.Finishes(tt)
+}
+
+// Equal reports whether tt and other's intervals share the same bounds.
+func (tt TimelineTime) Equal(other TimelineTime) bool {
+	return tt.lowerBound().Equal(other.lowerBound()) && tt.upperBound().Equal(other.upperBound())
+}
+
+// Merge combines tt with one or more other estimates of the same event into a single
+// best estimate, using an inverse-variance-weighted mean when uncertainties are present.
+// If any estimate has no uncertainty, the result is the unweighted mean of all
+// estimates' Seconds, with no uncertainty range.
+func (tt TimelineTime) Merge(others ...TimelineTime) TimelineTime {
+	all := append([]TimelineTime{tt}, others...)
+
+	weightSum := decimal.Zero
+	weightedSeconds := decimal.Zero
+	hasUncertainty := true
+
+	for _, est := range all {
+		if est.UncertaintyRange == nil || est.UncertaintyRange.IsZero() {
+			hasUncertainty = false
+			break
+		}
+		variance := est.UncertaintyRange.Mul(*est.UncertaintyRange)
+		weight := decimal.NewFromInt(1).Div(variance)
+		weightSum = weightSum.Add(weight)
+		weightedSeconds = weightedSeconds.Add(est.Seconds.Mul(weight))
+	}
+
+	if !hasUncertainty || weightSum.IsZero() {
+		// Fall back to an unweighted mean when any estimate has no uncertainty to weight by.
+		sum := decimal.Zero
+		for _, est := range all {
+			sum = sum.Add(est.Seconds)
+		}
+		mean := sum.Div(decimal.NewFromInt(int64(len(all))))
+		return NewTimelineTime(mean, tt.Precision)
+	}
+
+	mergedSeconds := weightedSeconds.Div(weightSum)
+	mergedUncertainty := decimalSqrt(decimal.NewFromInt(1).Div(weightSum))
+
+	return TimelineTime{
+		Seconds:          mergedSeconds,
+		Precision:        tt.Precision,
+		UncertaintyRange: &mergedUncertainty,
+	}
+}
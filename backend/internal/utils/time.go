@@ -51,9 +51,10 @@ const (
 
 // TimelineTime represents a point in time on the universal timeline
 type TimelineTime struct {
-	Seconds         decimal.Decimal // Seconds since Big Bang
-	Precision       PrecisionLevel
+	Seconds          decimal.Decimal  // Seconds since Big Bang
+	Precision        PrecisionLevel
 	UncertaintyRange *decimal.Decimal // Optional ± range in seconds
+	Correlated       bool             // If true, uncertainty combines linearly with other Correlated estimates instead of in quadrature
 }
 
 // UnixToTimeline converts a Unix timestamp to timeline seconds
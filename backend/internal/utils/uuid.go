@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+)
+
+// NamespaceTimelineUser is the namespace UUID used to derive deterministic
+// user IDs from (provider, providerUserID) pairs. It was generated once with
+// a random UUIDv4 and must never change, or every existing UUIDv5 derived
+// from it would no longer match on recompute.
+const NamespaceTimelineUser = "6f7b3c9a-6e0e-4c1d-9c3e-5f2a1b7d9e4f"
+
+// NewV5 derives an RFC 4122-compliant, name-based UUID (version 5: SHA-1)
+// from namespace and name, so the same inputs always produce the same UUID.
+// namespace must itself be a valid UUID string (see NamespaceTimelineUser).
+func NewV5(namespace, name string) (string, error) {
+	nsBytes, err := parseUUID(namespace)
+	if err != nil {
+		return "", fmt.Errorf("invalid namespace UUID: %w", err)
+	}
+
+	h := sha1.New()
+	h.Write(nsBytes)
+	h.Write([]byte(name))
+	sum := h.Sum(nil)
+
+	sum[6] = (sum[6] & 0x0f) | 0x50 // version 5
+	sum[8] = (sum[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	return formatUUID(sum[:16]), nil
+}
+
+// parseUUID strips the dashes from a canonical 8-4-4-4-12 UUID string and
+// decodes it to its 16 raw bytes.
+func parseUUID(s string) ([]byte, error) {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return nil, fmt.Errorf("malformed UUID %q", s)
+	}
+
+	hexStr := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	b, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// formatUUID renders 16 raw bytes as a canonical 8-4-4-4-12 UUID string.
+func formatUUID(b []byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}